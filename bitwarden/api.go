@@ -0,0 +1,439 @@
+package bitwarden
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// apiVault reads the vault from the server's http api, and decrypts it in go; rather than spawning bw for every read.
+// NOTE: the login is separate from bw's; it shows up as another device of the account.
+type apiVault struct {
+	fallback       vault // NOTE: for the filters that can't be done here; e.g. --url, which needs the equivalent domains.
+	http           *http.Client
+	apiUrl         string
+	identityUrl    string
+	deviceId       string
+	email          string
+	masterPassword string
+	clientId       string
+	clientSecret   string
+	twoStepMethod  int
+	twoStepCode    func() (string, error)
+	mutex          *sync.Mutex // NOTE: guards the tokens and keys below.
+	accessToken    string
+	refreshToken   string
+	masterKey      []byte
+	userKey        *symmetricKey
+	privateKey     *rsa.PrivateKey
+	orgKeys        map[string]*symmetricKey
+}
+
+// apiDeviceTypes are the bitwarden device types of the cli; bw's own, since this is a cli client too.
+var apiDeviceTypes = map[string]int{
+	"windows": 23,
+	"darwin":  24,
+}
+
+const apiDeviceTypeLinux = 25
+
+func newApiVault(c *Client) (*apiVault, error) {
+	if c.MasterPassword == "" {
+		return nil, fmt.Errorf("backend %q needs master_password; to decrypt the vault", backendApi)
+	}
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	deviceId, err := c.apiDeviceId()
+	if err != nil {
+		return nil, err
+	}
+	apiUrl, identityUrl := c.apiUrls()
+	v := &apiVault{
+		fallback:       &cliVault{c: c},
+		http:           httpClient,
+		apiUrl:         apiUrl,
+		identityUrl:    identityUrl,
+		deviceId:       deviceId,
+		email:          c.Email,
+		masterPassword: c.MasterPassword,
+		clientId:       c.clientId,
+		clientSecret:   c.clientSecret,
+		twoStepMethod:  c.twoStepMethod,
+		twoStepCode:    c.twoStepLoginCode,
+		mutex:          &sync.Mutex{},
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	err = v.login()
+	if err != nil {
+		return nil, err
+	}
+	err = v.loadKeys()
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// apiUrls gives the urls of the api and identity services; like bw does for the configured server.
+func (c *Client) apiUrls() (string, string) {
+	server := normalizeServerUrl(c.Server)
+	apiUrl, identityUrl := server+"/api", server+"/identity"
+	if server == defaultServer {
+		apiUrl, identityUrl = "https://api.bitwarden.com", "https://identity.bitwarden.com"
+	}
+	if url, ok := c.serverUrls["api"]; ok {
+		apiUrl = strings.TrimRight(url, "/")
+	}
+	if url, ok := c.serverUrls["identity"]; ok {
+		identityUrl = strings.TrimRight(url, "/")
+	}
+	return apiUrl, identityUrl
+}
+
+// httpClient trusts the same CA certs as bw does; see ca_cert_file, ca_cert_pem and insecure_skip_verify.
+func (c *Client) httpClient() (*http.Client, error) {
+	return newHttpClient(c.caCertFile, c.insecureSkipVerify)
+}
+
+func newHttpClient(caCertFile string, insecureSkipVerify bool) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if caCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool.AppendCertsFromPEM(pem)
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 60 * time.Second}, nil
+}
+
+// apiDeviceId is kept in the data dir; so a kept data_dir doesn't add a new device, and a new device email, every run.
+func (c *Client) apiDeviceId() (string, error) {
+	path := filepath.Join(c.DataDir, "api-device-id")
+	if existing, err := ioutil.ReadFile(path); err == nil && len(existing) > 0 {
+		return strings.TrimSpace(string(existing)), nil
+	}
+	deviceId, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, []byte(deviceId), 0600)
+	if err != nil {
+		return "", err
+	}
+	return deviceId, nil
+}
+
+type apiTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type apiErrorResponse struct {
+	Error              string      `json:"error"`
+	ErrorDescription   string      `json:"error_description"`
+	Message            string      `json:"message"`
+	TwoFactorProviders interface{} `json:"twoFactorProviders"`
+	ErrorModel         struct {
+		Message string `json:"message"`
+	} `json:"errorModel"`
+}
+
+func (e *apiErrorResponse) String() string {
+	for _, message := range []string{e.ErrorModel.Message, e.Message, e.ErrorDescription, e.Error} {
+		if message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+func (v *apiVault) deviceType() int {
+	if deviceType, ok := apiDeviceTypes[runtime.GOOS]; ok {
+		return deviceType
+	}
+	return apiDeviceTypeLinux
+}
+
+// login gets an access token; with the api key when given, otherwise with the master password.
+func (v *apiVault) login() error {
+	form := url.Values{
+		"scope":            {"api offline_access"},
+		"client_id":        {"cli"},
+		"deviceType":       {strconv.Itoa(v.deviceType())},
+		"deviceIdentifier": {v.deviceId},
+		"deviceName":       {"terraform-provider-bitwarden"},
+	}
+	header := http.Header{}
+	if v.clientId != "" && v.clientSecret != "" {
+		form.Set("grant_type", "client_credentials")
+		form.Set("scope", "api")
+		form.Set("client_id", v.clientId)
+		form.Set("client_secret", v.clientSecret)
+	} else {
+		masterKey, err := v.loadMasterKey()
+		if err != nil {
+			return err
+		}
+		form.Set("grant_type", "password")
+		form.Set("username", v.email)
+		form.Set("password", masterPasswordHash(masterKey, v.masterPassword))
+		header.Set("Auth-Email", base64.RawURLEncoding.EncodeToString([]byte(v.email)))
+		// NOTE: bw logs in with the same two-step code once a write first needs it; servers may decline it the second time. The api key avoids that.
+		twoStepCode, err := v.twoStepCode()
+		if err != nil {
+			return err
+		}
+		if twoStepCode != "" {
+			form.Set("twoFactorProvider", strconv.Itoa(v.twoStepMethod))
+			form.Set("twoFactorToken", twoStepCode)
+		}
+	}
+	return v.requestToken(form, header)
+}
+
+func (v *apiVault) refresh() error {
+	if v.refreshToken == "" { // NOTE: api key logins don't get one; they just log in again.
+		return v.login()
+	}
+	return v.requestToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {"cli"},
+		"refresh_token": {v.refreshToken},
+	}, http.Header{})
+}
+
+func (v *apiVault) requestToken(form url.Values, header http.Header) error {
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	status, body, err := v.send(http.MethodPost, v.identityUrl+"/connect/token", []byte(form.Encode()), header)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		var errorResponse apiErrorResponse
+		_ = json.Unmarshal(body, &errorResponse)
+		if errorResponse.TwoFactorProviders != nil {
+			return fmt.Errorf("cannot log in to the api: two-step login required; set two_step_code or two_step_totp_secret, or use client_id and client_secret")
+		}
+		return fmt.Errorf("cannot log in to the api: %d %s", status, errorResponse.String())
+	}
+	var token apiTokenResponse
+	err = json.Unmarshal(body, &token)
+	if err != nil || token.AccessToken == "" {
+		return fmt.Errorf("unexpected token output:\n%s", body)
+	}
+	v.accessToken = token.AccessToken
+	v.refreshToken = token.RefreshToken
+	return nil
+}
+
+func (v *apiVault) prelogin(email string) (*kdfParams, error) {
+	// NOTE: newer servers have prelogin under identity; older ones (and vaultwarden) under api.
+	body, err := json.Marshal(map[string]string{"email": email})
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{"Content-Type": {"application/json"}}
+	for _, base := range []string{v.identityUrl, v.apiUrl} {
+		status, response, err := v.send(http.MethodPost, base+"/accounts/prelogin", body, header)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusNotFound {
+			continue
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("cannot prelogin: %d %s", status, response)
+		}
+		var params kdfParams
+		err = json.Unmarshal(response, &params)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected prelogin output:\n%s", response)
+		}
+		return &params, nil
+	}
+	return nil, fmt.Errorf("cannot prelogin: not found on %s or %s", v.identityUrl, v.apiUrl)
+}
+
+// loadMasterKey derives the master key once; the kdf is deliberately slow.
+func (v *apiVault) loadMasterKey() ([]byte, error) {
+	if v.masterKey != nil {
+		return v.masterKey, nil
+	}
+	params, err := v.prelogin(v.email)
+	if err != nil {
+		return nil, err
+	}
+	v.masterKey, err = makeMasterKey(v.masterPassword, v.email, *params)
+	if err != nil {
+		return nil, err
+	}
+	return v.masterKey, nil
+}
+
+type apiProfile struct {
+	Email         string
+	Key           string
+	PrivateKey    string
+	Organizations []struct {
+		Id  string
+		Key string
+	}
+}
+
+// loadKeys decrypts the user's keys, and those of the user's organizations, from the synced profile.
+func (v *apiVault) loadKeys() error {
+	var sync struct {
+		Profile apiProfile
+	}
+	err := v.getLocked("/sync?excludeDomains=true", &sync)
+	if err != nil {
+		return err
+	}
+	profile := sync.Profile
+	if v.email == "" { // NOTE: api key logins needn't give the email; but it's the salt of the master key.
+		v.email = profile.Email
+	}
+	masterKey, err := v.loadMasterKey()
+	if err != nil {
+		return err
+	}
+	v.userKey, err = decryptUserKey(profile.Key, masterKey)
+	if err != nil {
+		return err
+	}
+	v.orgKeys = map[string]*symmetricKey{}
+	if len(profile.Organizations) == 0 {
+		return nil
+	}
+	v.privateKey, err = decryptPrivateKey(profile.PrivateKey, v.userKey)
+	if err != nil {
+		return err
+	}
+	for _, organization := range profile.Organizations {
+		orgKey, err := decryptOrganizationKey(organization.Key, v.privateKey)
+		if err != nil {
+			return fmt.Errorf("cannot decrypt the key of organization %s: %s", organization.Id, err)
+		}
+		v.orgKeys[organization.Id] = orgKey
+	}
+	return nil
+}
+
+func (v *apiVault) get(path string, out interface{}) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.getLocked(path, out)
+}
+
+// getLocked gets an api path as json; refreshing the access token once when it expired. The caller holds the mutex.
+func (v *apiVault) getLocked(path string, out interface{}) error {
+	var status int
+	var body []byte
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		status, body, err = v.send(http.MethodGet, v.apiUrl+path, nil, http.Header{"Authorization": {"Bearer " + v.accessToken}})
+		if err != nil {
+			return err
+		}
+		if status != http.StatusUnauthorized || attempt > 0 {
+			break
+		}
+		err = v.refresh()
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case status == http.StatusNotFound:
+		return fmt.Errorf("cannot get %s: %w", path, errNotFound)
+	case status != http.StatusOK:
+		var errorResponse apiErrorResponse
+		_ = json.Unmarshal(body, &errorResponse)
+		return fmt.Errorf("cannot get %s: %d %s", path, status, errorResponse.String())
+	}
+	var decoded interface{}
+	err = json.Unmarshal(body, &decoded)
+	if err != nil {
+		return fmt.Errorf("unexpected %s output:\n%s", path, body)
+	}
+	// NOTE: older vaultwarden versions give PascalCase keys; bw's output, and so the key conversions, are camelCase.
+	decoded = camelCaseKeys(decoded)
+	if asMap, ok := out.(*map[string]interface{}); ok {
+		if *asMap, ok = decoded.(map[string]interface{}); !ok {
+			return fmt.Errorf("unexpected %s output:\n%s", path, body)
+		}
+		return nil
+	}
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, out)
+}
+
+func (v *apiVault) send(method string, url string, body []byte, header http.Header) (int, []byte, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	request.Header = header
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Device-Type", strconv.Itoa(v.deviceType()))
+	request.Header.Set("User-Agent", "terraform-provider-bitwarden")
+	response, err := v.http.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return response.StatusCode, responseBody, nil
+}
+
+func camelCaseKeys(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			if key != "" {
+				key = strings.ToLower(key[:1]) + key[1:]
+			}
+			converted[key] = camelCaseKeys(child)
+		}
+		return converted
+	case []interface{}:
+		for i, child := range typed {
+			typed[i] = camelCaseKeys(child)
+		}
+		return typed
+	}
+	return value
+}
@@ -0,0 +1,208 @@
+package bitwarden
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	kdfPBKDF2   = 0
+	kdfArgon2id = 1
+)
+
+// kdfParams are the key derivation settings of an account; as given by prelogin.
+type kdfParams struct {
+	Kdf            int
+	KdfIterations  int
+	KdfMemory      int // NOTE: in MiB; argon2id only.
+	KdfParallelism int // NOTE: argon2id only.
+}
+
+// symmetricKey is a vault key; the user's, an organization's or a cipher's.
+type symmetricKey struct {
+	enc []byte
+	mac []byte // NOTE: empty for the legacy (type 0) keys of old accounts.
+}
+
+func makeMasterKey(password string, email string, params kdfParams) ([]byte, error) {
+	salt := []byte(strings.ToLower(strings.TrimSpace(email)))
+	switch params.Kdf {
+	case kdfPBKDF2:
+		return pbkdf2.Key([]byte(password), salt, params.KdfIterations, 32, sha256.New), nil
+	case kdfArgon2id:
+		saltHash := sha256.Sum256(salt)
+		return argon2.IDKey([]byte(password), saltHash[:], uint32(params.KdfIterations), uint32(params.KdfMemory)*1024, uint8(params.KdfParallelism), 32), nil
+	}
+	return nil, fmt.Errorf("unsupported kdf type %d", params.Kdf)
+}
+
+// masterPasswordHash is what the server gets to check the master password; the master key itself never leaves the client.
+func masterPasswordHash(masterKey []byte, password string) string {
+	return base64.StdEncoding.EncodeToString(pbkdf2.Key(masterKey, []byte(password), 1, 32, sha256.New))
+}
+
+func stretchMasterKey(masterKey []byte) (*symmetricKey, error) {
+	key := &symmetricKey{enc: make([]byte, 32), mac: make([]byte, 32)}
+	for _, part := range []struct {
+		info string
+		out  []byte
+	}{{"enc", key.enc}, {"mac", key.mac}} {
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, masterKey, []byte(part.info)), part.out); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func newSymmetricKey(raw []byte) (*symmetricKey, error) {
+	switch len(raw) {
+	case 32:
+		return &symmetricKey{enc: raw}, nil
+	case 64:
+		return &symmetricKey{enc: raw[:32], mac: raw[32:]}, nil
+	}
+	return nil, fmt.Errorf("unexpected key length %d", len(raw))
+}
+
+// decryptUserKey decrypts the user's key (profile.key) with the master key.
+func decryptUserKey(encryptedKey string, masterKey []byte) (*symmetricKey, error) {
+	wrappingKey := &symmetricKey{enc: masterKey} // NOTE: old accounts have their key encrypted with the unstretched master key; without mac.
+	if !strings.HasPrefix(encryptedKey, "0.") {
+		var err error
+		wrappingKey, err = stretchMasterKey(masterKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	raw, err := wrappingKey.decrypt(encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt the user key; is the master password right? %w", err)
+	}
+	return newSymmetricKey(raw)
+}
+
+var errInvalidMac = errors.New("invalid mac")
+
+// decrypt decrypts an encrypted string of type 0 (AES-CBC-256) or 2 (AES-CBC-256 with HMAC-SHA256); formatted as <type>.<iv>|<data>|<mac>.
+func (key *symmetricKey) decrypt(encString string) ([]byte, error) {
+	dot := strings.Index(encString, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("unexpected encrypted string format")
+	}
+	encType, parts := encString[:dot], strings.Split(encString[dot+1:], "|")
+	var iv, data, mac []byte
+	var err error
+	switch {
+	case encType == "0" && len(parts) == 2:
+	case encType == "2" && len(parts) == 3:
+		mac, err = base64.StdEncoding.DecodeString(parts[2])
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported encrypted string type %s", encType)
+	}
+	if iv, err = base64.StdEncoding.DecodeString(parts[0]); err != nil {
+		return nil, err
+	}
+	if data, err = base64.StdEncoding.DecodeString(parts[1]); err != nil {
+		return nil, err
+	}
+
+	if mac != nil {
+		if key.mac == nil {
+			return nil, fmt.Errorf("key has no mac key for encrypted string type %s", encType)
+		}
+		expected := hmac.New(sha256.New, key.mac)
+		expected.Write(iv)
+		expected.Write(data)
+		if !hmac.Equal(mac, expected.Sum(nil)) {
+			return nil, errInvalidMac
+		}
+	}
+
+	block, err := aes.NewCipher(key.enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize || len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("unexpected encrypted data length")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+	return unpadPKCS7(plain)
+}
+
+func (key *symmetricKey) decryptString(encString string) (string, error) {
+	plain, err := key.decrypt(encString)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func unpadPKCS7(data []byte) ([]byte, error) {
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(data) || !bytes.Equal(data[len(data)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return data[:len(data)-padding], nil
+}
+
+func decryptPrivateKey(encryptedKey string, userKey *symmetricKey) (*rsa.PrivateKey, error) {
+	der, err := userKey.decrypt(encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt the private key: %s", err)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected private key type %T", parsed)
+	}
+	return privateKey, nil
+}
+
+// decryptOrganizationKey decrypts an organization's key; encrypted with the user's public key (RSA-OAEP; type 3 with SHA-256, type 4 with SHA-1).
+func decryptOrganizationKey(encString string, privateKey *rsa.PrivateKey) (*symmetricKey, error) {
+	dot := strings.Index(encString, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("unexpected encrypted string format")
+	}
+	var hashFunc hash.Hash
+	switch encString[:dot] {
+	case "3":
+		hashFunc = sha256.New()
+	case "4":
+		hashFunc = sha1.New()
+	default:
+		return nil, fmt.Errorf("unsupported organization key type %s", encString[:dot])
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Split(encString[dot+1:], "|")[0])
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rsa.DecryptOAEP(hashFunc, nil, privateKey, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newSymmetricKey(raw)
+}
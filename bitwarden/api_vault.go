@@ -0,0 +1,313 @@
+package bitwarden
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// encStringPattern matches the encrypted strings of the symmetric types; see symmetricKey.decrypt.
+var encStringPattern = regexp.MustCompile(`^[02]\.[A-Za-z0-9+/=]+\|[A-Za-z0-9+/=]+(\|[A-Za-z0-9+/=]+)?$`)
+
+// apiCipherKeysToDrop are the keys of the api's ciphers that bw doesn't give; or that only make sense encrypted.
+var apiCipherKeysToDrop = []string{"key", "data", "edit", "viewPassword", "organizationUseTotp", "permissions"}
+
+func (v *apiVault) getItem(id string) (*map[string]interface{}, error) {
+	var cipher map[string]interface{}
+	err := v.get(fmt.Sprintf("/ciphers/%s/details", id), &cipher)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get item: %w", err)
+	}
+	item, err := v.decryptCipher(cipher)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (v *apiVault) listItems(filters ...string) (*[]interface{}, error) {
+	// NOTE: filters are emulated like bw applies them; the ones that can't be (e.g. --url, --trash) are left to bw.
+	filterValues := map[string]string{}
+	for i := 0; i+1 < len(filters); i += 2 {
+		switch filters[i] {
+		case "--search", "--folderid", "--collectionid", "--organizationid":
+			filterValues[filters[i]] = filters[i+1]
+		default:
+			return v.fallback.listItems(filters...)
+		}
+	}
+	if len(filters)%2 != 0 {
+		return v.fallback.listItems(filters...)
+	}
+
+	var ciphers struct {
+		Data []map[string]interface{}
+	}
+	err := v.get("/ciphers", &ciphers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list items: %w", err)
+	}
+	var items []map[string]interface{}
+	for _, cipher := range ciphers.Data {
+		if cipher["deletedDate"] != nil {
+			continue
+		}
+		item, err := v.decryptCipher(cipher)
+		if err != nil {
+			return nil, err
+		}
+		if matchesItemFilters(*item, filterValues) {
+			items = append(items, *item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return strings.ToLower(fmt.Sprint(items[i]["name"])) < strings.ToLower(fmt.Sprint(items[j]["name"]))
+	})
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = item
+	}
+	return &list, nil
+}
+
+func (v *apiVault) getFolder(id string) (*map[string]interface{}, error) {
+	var folder struct {
+		Id   string
+		Name string
+	}
+	err := v.get(fmt.Sprintf("/folders/%s", id), &folder)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get folder: %w", err)
+	}
+	name, err := v.userKeyLocked().decryptString(folder.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt folder %s: %s", folder.Id, err)
+	}
+	return &map[string]interface{}{
+		"object": "folder",
+		"id":     folder.Id,
+		"name":   name,
+	}, nil
+}
+
+func (v *apiVault) listFolders(filters ...string) (*[]interface{}, error) {
+	search := ""
+	if len(filters) == 2 && filters[0] == "--search" {
+		search = strings.ToLower(strings.TrimSpace(filters[1]))
+	} else if len(filters) > 0 {
+		return v.fallback.listFolders(filters...)
+	}
+
+	var folders struct {
+		Data []struct {
+			Id   string
+			Name string
+		}
+	}
+	err := v.get("/folders", &folders)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list folders: %w", err)
+	}
+	userKey := v.userKeyLocked()
+	var list []interface{}
+	for _, folder := range folders.Data {
+		name, err := userKey.decryptString(folder.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt folder %s: %s", folder.Id, err)
+		}
+		list = append(list, map[string]interface{}{
+			"object": "folder",
+			"id":     folder.Id,
+			"name":   name,
+		})
+	}
+	list = append(list, map[string]interface{}{ // NOTE: bw lists it too.
+		"object": "folder",
+		"id":     nil,
+		"name":   "No Folder",
+	})
+	var matches []interface{}
+	for _, folder := range list {
+		if strings.Contains(strings.ToLower(folder.(map[string]interface{})["name"].(string)), search) {
+			matches = append(matches, folder)
+		}
+	}
+	return &matches, nil
+}
+
+func (v *apiVault) userKeyLocked() *symmetricKey {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.userKey
+}
+
+// cipherKey gives the key a cipher's strings are encrypted with; the organization's or the user's, or the cipher's own key encrypted with either.
+func (v *apiVault) cipherKey(cipher map[string]interface{}) (*symmetricKey, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	key := v.userKey
+	if organizationId, ok := cipher["organizationId"].(string); ok && organizationId != "" {
+		if _, known := v.orgKeys[organizationId]; !known { // NOTE: the organization was joined after login.
+			err := v.loadKeys()
+			if err != nil {
+				return nil, err
+			}
+		}
+		key, ok = v.orgKeys[organizationId]
+		if !ok {
+			return nil, fmt.Errorf("no key for organization %s", organizationId)
+		}
+	}
+	if encryptedKey, ok := cipher["key"].(string); ok && encryptedKey != "" {
+		raw, err := key.decrypt(encryptedKey)
+		if err != nil {
+			return nil, err
+		}
+		return newSymmetricKey(raw)
+	}
+	return key, nil
+}
+
+// decryptCipher turns a cipher from the api into an item like bw gives it.
+func (v *apiVault) decryptCipher(cipher map[string]interface{}) (*map[string]interface{}, error) {
+	key, err := v.cipherKey(cipher)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt item %v: %s", cipher["id"], err)
+	}
+	for _, drop := range apiCipherKeysToDrop {
+		delete(cipher, drop)
+	}
+	decrypted, err := decryptStrings(cipher, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt item %v: %s", cipher["id"], err)
+	}
+	item := decrypted.(map[string]interface{})
+	item["object"] = "item"
+	if item["collectionIds"] == nil {
+		item["collectionIds"] = []interface{}{}
+	}
+	err = convertKeys(&item, &itemKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// decryptStrings decrypts the encrypted strings anywhere in value; leaving the rest (ids, dates, types etc.) as is.
+func decryptStrings(value interface{}, key *symmetricKey) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		delete(typed, "key") // NOTE: e.g. attachment keys; only useful to download them, which is left to bw.
+		for childKey, child := range typed {
+			decrypted, err := decryptStrings(child, key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", childKey, err)
+			}
+			typed[childKey] = decrypted
+		}
+		return typed, nil
+	case []interface{}:
+		for i, child := range typed {
+			decrypted, err := decryptStrings(child, key)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = decrypted
+		}
+		return typed, nil
+	case string:
+		if !encStringPattern.MatchString(typed) {
+			return typed, nil
+		}
+		return key.decryptString(typed)
+	}
+	return value, nil
+}
+
+// matchesItemFilters applies the filters like 'bw list items' does; including its "null" and "notnull" values. item has itemKeyConversion applied.
+func matchesItemFilters(item map[string]interface{}, filters map[string]string) bool {
+	for filter, value := range filters {
+		var matches bool
+		switch filter {
+		case "--search":
+			matches = matchesSearch(item, value)
+		case "--folderid":
+			matches = matchesIdFilter(item["folder_id"], value)
+		case "--organizationid":
+			matches = matchesIdFilter(item["organization_id"], value)
+		case "--collectionid":
+			collectionIds, _ := item["collection_ids"].([]interface{})
+			switch value {
+			case "null":
+				matches = len(collectionIds) == 0
+			case "notnull":
+				matches = len(collectionIds) > 0
+			default:
+				for _, collectionId := range collectionIds {
+					matches = matches || collectionId == value
+				}
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesIdFilter(id interface{}, value string) bool {
+	switch value {
+	case "null":
+		return id == nil
+	case "notnull":
+		return id != nil
+	}
+	return id == value
+}
+
+// matchesSearch matches like bw's basic search; on the name, the id's start, the subtitle (e.g. a login's username), and a login's first uri.
+func matchesSearch(item map[string]interface{}, search string) bool {
+	search = strings.ToLower(strings.TrimSpace(search))
+	contains := func(value interface{}) bool {
+		text, ok := value.(string)
+		return ok && strings.Contains(strings.ToLower(text), search)
+	}
+	if contains(item["name"]) {
+		return true
+	}
+	if id, ok := item["id"].(string); ok && len(search) >= 8 && strings.HasPrefix(id, search) {
+		return true
+	}
+	if login, ok := item["login"].(map[string]interface{}); ok {
+		if contains(login["username"]) {
+			return true
+		}
+		if uris, ok := login["uris"].([]interface{}); ok && len(uris) > 0 {
+			if uri, ok := uris[0].(map[string]interface{}); ok && contains(uri["uri"]) {
+				return true
+			}
+		}
+	}
+	if card, ok := item["card"].(map[string]interface{}); ok {
+		subtitle := stringOrEmpty(card["brand"])
+		if number := stringOrEmpty(card["number"]); len(number) >= 4 {
+			subtitle = fmt.Sprintf("%s, *%s", subtitle, number[len(number)-4:])
+		}
+		if contains(subtitle) {
+			return true
+		}
+	}
+	if identity, ok := item["identity"].(map[string]interface{}); ok {
+		name := strings.TrimSpace(fmt.Sprintf("%s %s", stringOrEmpty(identity["first_name"]), stringOrEmpty(identity["last_name"])))
+		if contains(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringOrEmpty(value interface{}) string {
+	text, _ := value.(string)
+	return text
+}
@@ -0,0 +1,92 @@
+package bitwarden
+
+import (
+	"testing"
+)
+
+func TestMatchesItemFilters(t *testing.T) {
+	login := map[string]interface{}{
+		"id":              "0a1b2c3d-4e5f-6789-abcd-ef0123456789",
+		"name":            "Work Mail",
+		"folder_id":       "f1",
+		"organization_id": nil,
+		"collection_ids":  []interface{}{},
+		"login": map[string]interface{}{
+			"username": "Alice",
+			"uris": []interface{}{
+				map[string]interface{}{"uri": "https://mail.example.com"},
+				map[string]interface{}{"uri": "https://second.example.org"},
+			},
+		},
+	}
+	card := map[string]interface{}{
+		"id":              "9f8e7d6c-5b4a-3210-fedc-ba9876543210",
+		"name":            "Company card",
+		"folder_id":       nil,
+		"organization_id": "o1",
+		"collection_ids":  []interface{}{"c1", "c2"},
+		"card":            map[string]interface{}{"brand": "Visa", "number": "4111111111111234"},
+	}
+	identity := map[string]interface{}{
+		"id":              "11111111-2222-3333-4444-555555555555",
+		"name":            "Passport",
+		"folder_id":       nil,
+		"organization_id": "o2",
+		"identity":        map[string]interface{}{"first_name": "Jane", "last_name": "Doe"},
+	}
+
+	tests := []struct {
+		name     string
+		item     map[string]interface{}
+		filters  map[string]string
+		expected bool
+	}{
+		{"no filters", login, map[string]string{}, true},
+
+		{"search name", login, map[string]string{"--search": "work"}, true},
+		{"search name ignores case and spaces", login, map[string]string{"--search": " MAIL "}, true},
+		{"search username", login, map[string]string{"--search": "alice"}, true},
+		{"search first uri", login, map[string]string{"--search": "mail.example.com"}, true},
+		{"search other uris", login, map[string]string{"--search": "second.example.org"}, false},
+		{"search id prefix", login, map[string]string{"--search": "0a1b2c3d"}, true},
+		{"search short id prefix", login, map[string]string{"--search": "0a1b2c3"}, false},
+		{"search id middle", login, map[string]string{"--search": "4e5f-6789"}, false},
+		{"search card brand", card, map[string]string{"--search": "visa"}, true},
+		{"search card last digits", card, map[string]string{"--search": "*1234"}, true},
+		{"search card full number", card, map[string]string{"--search": "4111111111111234"}, false},
+		{"search identity name", identity, map[string]string{"--search": "jane doe"}, true},
+		{"search no match", login, map[string]string{"--search": "bank"}, false},
+
+		{"folderid", login, map[string]string{"--folderid": "f1"}, true},
+		{"folderid other", login, map[string]string{"--folderid": "f2"}, false},
+		{"folderid null", card, map[string]string{"--folderid": "null"}, true},
+		{"folderid null with folder", login, map[string]string{"--folderid": "null"}, false},
+		{"folderid notnull", login, map[string]string{"--folderid": "notnull"}, true},
+		{"folderid notnull without folder", card, map[string]string{"--folderid": "notnull"}, false},
+
+		{"organizationid", card, map[string]string{"--organizationid": "o1"}, true},
+		{"organizationid other", card, map[string]string{"--organizationid": "o2"}, false},
+		{"organizationid null", login, map[string]string{"--organizationid": "null"}, true},
+		{"organizationid null in org", card, map[string]string{"--organizationid": "null"}, false},
+		{"organizationid notnull", card, map[string]string{"--organizationid": "notnull"}, true},
+		{"organizationid notnull outside org", login, map[string]string{"--organizationid": "notnull"}, false},
+
+		{"collectionid", card, map[string]string{"--collectionid": "c2"}, true},
+		{"collectionid other", card, map[string]string{"--collectionid": "c3"}, false},
+		{"collectionid null", login, map[string]string{"--collectionid": "null"}, true},
+		{"collectionid null without collection_ids", identity, map[string]string{"--collectionid": "null"}, true},
+		{"collectionid null in collections", card, map[string]string{"--collectionid": "null"}, false},
+		{"collectionid notnull", card, map[string]string{"--collectionid": "notnull"}, true},
+		{"collectionid notnull outside collections", login, map[string]string{"--collectionid": "notnull"}, false},
+
+		{"all filters", card, map[string]string{"--search": "company", "--folderid": "null", "--organizationid": "o1", "--collectionid": "c1"}, true},
+		{"all filters but one", card, map[string]string{"--search": "company", "--folderid": "null", "--organizationid": "o2", "--collectionid": "c1"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if matches := matchesItemFilters(test.item, test.filters); matches != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, matches)
+			}
+		})
+	}
+}
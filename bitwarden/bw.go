@@ -3,15 +3,15 @@ package bitwarden
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
-
-	"os/exec"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
 func (c *Client) bwLoginCheck() (bool, error) {
-	cmd := exec.Command(c.BitwardenCLIBinary, "login", "--check", "--response")
+	cmd := c.command("login", "--check", "--response")
 	isLoggedIn, err := c.runAndCheckSucceeded(cmd, "login --check", 1)
 	if err != nil {
 		return false, err
@@ -20,7 +20,7 @@ func (c *Client) bwLoginCheck() (bool, error) {
 }
 
 func (c *Client) bwUnlockCheck() (bool, error) {
-	cmd := exec.Command(c.BitwardenCLIBinary, "unlock", "--check", "--response", "--session", c.SessionKey)
+	cmd := c.command("unlock", "--check", "--response")
 	isUnlocked, err := c.runAndCheckSucceeded(cmd, "unlock --check", 1)
 	if err != nil {
 		return false, err
@@ -35,7 +35,21 @@ func (c *Client) bwLogin() error {
 	}
 	c.mutexAuth.Lock()
 	defer c.mutexAuth.Unlock()
-	cmd := exec.Command(c.BitwardenCLIBinary, "login", "--response", c.Email)
+	if c.clientId != "" && c.clientSecret != "" {
+		return c.bwLoginApiKey()
+	}
+	if c.MasterPassword == "" {
+		return fmt.Errorf("not logged in to bw in %s; session_key needs the bw login it came from (set data_dir to its data dir), or set master_password instead", c.DataDir)
+	}
+	args := []string{"login", "--response", c.Email}
+	twoStepCode, err := c.twoStepLoginCode()
+	if err != nil {
+		return err
+	}
+	if twoStepCode != "" {
+		args = append(args, "--method", strconv.Itoa(c.twoStepMethod), "--code", twoStepCode)
+	}
+	cmd := c.command(args...)
 	sessionData, err := c.runGivingPasswordExpectingSuccess(cmd, "login", nil)
 	if err != nil {
 		return err
@@ -45,7 +59,30 @@ func (c *Client) bwLogin() error {
 	if err != nil {
 		return err
 	}
-	c.SessionKey = login.raw
+	c.SessionKey = login.Raw
+	return nil
+}
+
+func (c *Client) twoStepLoginCode() (string, error) {
+	if c.twoStepTotpSecret == "" {
+		return c.twoStepCode, nil
+	}
+	totp, err := parseTotpKey(c.twoStepTotpSecret)
+	if err != nil {
+		return "", fmt.Errorf("invalid two_step_totp_secret: %s", err)
+	}
+	return totp.code(time.Now()), nil
+}
+
+func (c *Client) bwLoginApiKey() error {
+	// NOTE: doesn't give a session key; the vault is unlocked with the master password afterwards, by ensureUnlocked.
+	// The api key is only given to the child's env; never as args.
+	cmd := c.command("login", "--apikey", "--response")
+	cmd.Env = append(cmd.Env, fmt.Sprintf("BW_CLIENTID=%s", c.clientId), fmt.Sprintf("BW_CLIENTSECRET=%s", c.clientSecret))
+	_, err := c.runExpectingSuccess(cmd, "login --apikey", nil)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -60,7 +97,7 @@ func (c *Client) bwLogout() error { // TODO should never do?
 	}
 	c.mutexAuth.Lock()
 	defer c.mutexAuth.Unlock()
-	cmd := exec.Command(c.BitwardenCLIBinary, "logout", "--response")
+	cmd := c.command("logout", "--response")
 	_, err = c.runAndCheckSucceeded(cmd, "logout", 1)
 	if err != nil {
 		return err
@@ -75,7 +112,7 @@ func (c *Client) bwSync() error {
 	}
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	cmd := exec.Command(c.BitwardenCLIBinary, "sync", "--response") // NOTE: seems to sometimes ask for password even when giving session token.
+	cmd := c.command("sync", "--response") // NOTE: seems to sometimes ask for password even when giving session token.
 	_, err = c.runGivingPasswordExpectingSuccess(cmd, "sync", nil)
 	if err != nil {
 		return err
@@ -83,35 +120,148 @@ func (c *Client) bwSync() error {
 	return nil
 }
 
-func (c *Client) bwListItems() (*[]interface{}, error) {
-	cmd := exec.Command(c.BitwardenCLIBinary, "list", "items", "--response", "--session", c.SessionKey)
+// itemKeyConversion renames the camelCase keys of an item to the snake_case used in the schema.
+var itemKeyConversion = map[string]interface{}{
+	"organizationId": "organization_id",
+	"folderId":       "folder_id",
+	"card": map[string]interface{}{
+		"cardholderName": "cardholder_name",
+		"expMonth":       "exp_month",
+		"expYear":        "exp_year",
+	},
+	"identity": map[string]interface{}{
+		"firstName":      "first_name",
+		"middleName":     "middle_name",
+		"lastName":       "last_name",
+		"postalCode":     "postal_code",
+		"passportNumber": "passport_number",
+		"licenseNumber":  "license_number",
+	},
+	"login": map[string]interface{}{
+		"passwordRevisionDate": "password_revision_date",
+	},
+	"secureNote":    "secure_note",
+	"collectionIds": "collection_ids",
+	"revisionDate":  "revision_date",
+	"attachments": []map[string]interface{}{
+		{
+			"fileName": "file_name",
+			"sizeName": "size_name",
+		},
+	},
+	"fields": []map[string]interface{}{
+		{
+			"linkedId": "linked_id",
+		},
+	},
+}
+
+func (c *Client) bwGetItem(id string) (*map[string]interface{}, error) {
+	cmd := c.command("get", "item", id, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "get item", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwGetTemplate(object string, keyConversion map[string]interface{}) (*map[string]interface{}, error) {
+	// NOTE: the template is wrapped like status is; {"object": "template", "template": {...}}.
+	cmd := c.command("get", "template", object, "--response")
+	data, err := c.runExpectingSuccess(cmd, "get template", &map[string]interface{}{
+		"data": map[string]interface{}{
+			"template": keyConversion,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	template, ok := (*data)["template"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected get template output:\n%v", *data)
+	}
+	return &template, nil
+}
+
+func (c *Client) bwCreateItem(item *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedItem, err := encodeObject(item, &itemKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("create", "item", encodedItem, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "create item", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwEditItem(id string, item *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedItem, err := encodeObject(item, &itemKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("edit", "item", id, encodedItem, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "edit item", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwDeleteItem(id string) error {
+	cmd := c.command("delete", "item", id, "--response")
+	_, err := c.runGivingPasswordExpectingSuccess(cmd, "delete item", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) bwMoveItem(id string, organizationId string, collectionIds []interface{}) (*map[string]interface{}, error) {
+	// NOTE: shares a personal item with an organization; the id stays the same.
+	encodedCollectionIds, err := encodeList(collectionIds)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("move", id, organizationId, encodedCollectionIds, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "move", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwEditItemCollections(id string, organizationId string, collectionIds []interface{}) (*map[string]interface{}, error) {
+	encodedCollectionIds, err := encodeList(collectionIds)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("edit", "item-collections", id, encodedCollectionIds, "--organizationid", organizationId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "edit item-collections", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwListItems(filters ...string) (*[]interface{}, error) {
+	// NOTE: filters are extra args; e.g. "--search", term or "--folderid", id.
+	args := append([]string{"list", "items", "--response"}, filters...)
+	cmd := c.command(args...)
 	data, err := c.runGivingPasswordExpectingSuccess(cmd, "list items", &map[string]interface{}{
 		"data": map[string]interface{}{
-			"data": []map[string]interface{}{
-				{
-					"organizationId": "organization_id",
-					"folderId":       "folder_id",
-					"card": map[string]interface{}{
-						"cardholderName": "cardholder_name",
-						"expMonth":       "exp_month",
-						"expYear":        "exp_year",
-					},
-					"identity": map[string]interface{}{
-						"firstName":      "first_name",
-						"middleName":     "middle_name",
-						"lastName":       "last_name",
-						"postalCode":     "postal_code",
-						"passportNumber": "passport_number",
-						"licenseNumber":  "license_number",
-					},
-					"login": map[string]interface{}{
-						"passwordRevisionDate": "password_revision_date",
-					},
-					"secureNote":    "secure_note",
-					"collectionIds": "collection_ids",
-					"revisionDate":  "revision_date",
-				},
-			},
+			"data": []map[string]interface{}{itemKeyConversion},
 		},
 	})
 	if err != nil {
@@ -134,7 +284,7 @@ func (c *Client) bwLock() error {
 	}
 	c.mutexAuth.Lock()
 	defer c.mutexAuth.Unlock()
-	cmd := exec.Command(c.BitwardenCLIBinary, "lock", "--response")
+	cmd := c.command("lock", "--response")
 	_, err = c.runAndCheckSucceeded(cmd, "lock", 1)
 	if err != nil {
 		return err
@@ -143,17 +293,17 @@ func (c *Client) bwLock() error {
 }
 
 type SessionData struct { // NOTE: matches format for both login and unlock.
-	noColor bool
-	object  string
-	title   string
-	message string
-	raw     string
+	NoColor bool
+	Object  string
+	Title   string
+	Message string
+	Raw     string
 }
 
 func (c *Client) bwUnlock() error {
 	c.mutexAuth.Lock()
 	defer c.mutexAuth.Unlock()
-	cmd := exec.Command(c.BitwardenCLIBinary, "unlock", "--response")
+	cmd := c.command("unlock", "--response")
 	sessionData, err := c.runGivingPasswordExpectingSuccess(cmd, "unlock", nil)
 	if err != nil {
 		return err
@@ -163,12 +313,48 @@ func (c *Client) bwUnlock() error {
 	if err != nil {
 		return err
 	}
-	c.SessionKey = unlock.raw
+	c.SessionKey = unlock.Raw
+	return nil
+}
+
+func (c *Client) bwGenerate(options ...string) (string, error) {
+	args := append([]string{"generate", "--response"}, options...)
+	cmd := c.command(args...)
+	data, err := c.runExpectingSuccess(cmd, "generate", nil)
+	if err != nil {
+		return "", err
+	}
+	if generated, ok := (*data)["data"].(string); ok {
+		return generated, nil
+	} else {
+		return "", fmt.Errorf("unexpected bwGenerate output:\n%v", data)
+	}
+}
+
+func (c *Client) bwConfigServer() error {
+	// NOTE: only works while logged out.
+	if _, ok := c.serverUrls["key-connector"]; ok {
+		err := c.checkCliFeature(cliFeatureKeyConnector) // NOTE: runs during the setup; see ensureCliSetUp.
+		if err != nil {
+			return err
+		}
+	}
+	args := []string{"config", "server", c.Server, "--response"}
+	for _, flag := range serverUrlFlags {
+		if url, ok := c.serverUrls[flag]; ok {
+			args = append(args, "--"+flag, url)
+		}
+	}
+	cmd := c.command(args...)
+	_, err := c.runExpectingSuccess(cmd, "config server", nil)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
 func (c *Client) bwVersion() (string, error) {
-	cmd := exec.Command(c.BitwardenCLIBinary, "--version")
+	cmd := c.command("--version")
 	version, err := c.runOnly(cmd, "check version", 0)
 	if err != nil {
 		return "", err
@@ -190,7 +376,7 @@ type StatusOuter struct {
 }
 
 func (c *Client) bwStatus() (*Status, error) {
-	cmd := exec.Command(c.BitwardenCLIBinary, "status", "--response", "--session", c.SessionKey)
+	cmd := c.command("status", "--response")
 	var statusOuter StatusOuter
 	statusData, err := c.runExpectingSuccess(cmd, "status", nil)
 	if err != nil {
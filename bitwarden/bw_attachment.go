@@ -0,0 +1,48 @@
+package bitwarden
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func (c *Client) bwGetAttachment(itemId string, id string) (*[]byte, error) {
+	// NOTE: saved to a temporary file, rather than read from stdout, so binary content isn't mixed up with prompts.
+	dir, err := ioutil.TempDir("", "terraform-provider-bitwarden")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "attachment")
+	cmd := c.command("get", "attachment", id, "--itemid", itemId, "--output", output, "--response")
+	_, err = c.runGivingPasswordExpectingSuccess(cmd, "get attachment", nil)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(output)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+func (c *Client) bwCreateAttachment(itemId string, file string) (*map[string]interface{}, error) {
+	// NOTE: returns the item the attachment was added to.
+	cmd := c.command("create", "attachment", "--file", file, "--itemid", itemId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "create attachment", &map[string]interface{}{
+		"data": itemKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwDeleteAttachment(itemId string, id string) error {
+	cmd := c.command("delete", "attachment", id, "--itemid", itemId, "--response")
+	_, err := c.runGivingPasswordExpectingSuccess(cmd, "delete attachment", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package bitwarden
+
+import (
+	"fmt"
+)
+
+func (c *Client) bwGetFolder(id string) (*map[string]interface{}, error) {
+	cmd := c.command("get", "folder", id, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "get folder", nil)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwListFolders(filters ...string) (*[]interface{}, error) {
+	args := append([]string{"list", "folders", "--response"}, filters...)
+	cmd := c.command(args...)
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "list folders", nil)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := (*data)["data"].([]interface{}); ok {
+		return &list, nil
+	} else {
+		return nil, fmt.Errorf("unexpected bwListFolders output:\n%v", data)
+	}
+}
+
+func (c *Client) bwCreateFolder(folder *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedFolder, err := encodeObject(folder, nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("create", "folder", encodedFolder, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "create folder", nil)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwEditFolder(id string, folder *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedFolder, err := encodeObject(folder, nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("edit", "folder", id, encodedFolder, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "edit folder", nil)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwDeleteFolder(id string) error {
+	cmd := c.command("delete", "folder", id, "--response")
+	_, err := c.runGivingPasswordExpectingSuccess(cmd, "delete folder", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
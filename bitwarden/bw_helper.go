@@ -1,7 +1,9 @@
 package bitwarden
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mitchellh/mapstructure"
 	"io"
@@ -40,6 +42,73 @@ func UnmarshalConvertKeys(data *[]byte, keyConversion *map[string]interface{}) (
 	return &generic, nil
 }
 
+func MarshalConvertKeys(data *map[string]interface{}, keyConversion *map[string]interface{}) (*[]byte, error) {
+	// NOTE: keyConversion is given in the same direction as for UnmarshalConvertKeys; it's inverted here.
+	// NOTE: data is converted in place.
+	invertedKeyConversion, err := invertKeyConversion(keyConversion)
+	if err != nil {
+		return nil, err
+	}
+	err = convertKeys(data, invertedKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	output, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func invertKeyConversion(keyConversion *map[string]interface{}) (*map[string]interface{}, error) {
+	if keyConversion == nil {
+		return nil, nil
+	}
+	inverted := map[string]interface{}{}
+	for key, element := range *keyConversion {
+		switch value := element.(type) {
+		case string:
+			inverted[value] = key
+		case []map[string]interface{}:
+			var invertedList []map[string]interface{}
+			for _, keyConversionListItem := range value {
+				invertedListItem, err := invertKeyConversion(&keyConversionListItem)
+				if err != nil {
+					return nil, err
+				}
+				invertedList = append(invertedList, *invertedListItem)
+			}
+			inverted[key] = invertedList
+		case map[string]interface{}:
+			invertedChild, err := invertKeyConversion(&value)
+			if err != nil {
+				return nil, err
+			}
+			inverted[key] = *invertedChild
+		default:
+			return nil, fmt.Errorf("Unexpected keyConversion format:\n%v", keyConversion)
+		}
+	}
+	return &inverted, nil
+}
+
+func encodeObject(data *map[string]interface{}, keyConversion *map[string]interface{}) (string, error) {
+	// NOTE: equivalent of piping the json through 'bw encode'.
+	output, err := MarshalConvertKeys(data, keyConversion)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(*output), nil
+}
+
+func encodeList(data []interface{}) (string, error) {
+	output, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(output), nil
+}
+
 func convertKeys(input *map[string]interface{}, keyConversion *map[string]interface{}) error {
 	// careful: https://stackoverflow.com/questions/45132563/idiomatic-way-of-renaming-keys-in-map-while-ranging-over-the-original-map
 	// TODO handle lists in parallel? this could be a bottleneck.
@@ -171,8 +240,8 @@ func (c *Client) checkCorrectUser() (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	if status.ServerUrl != c.Server { // NOTE: server is always known, so always check.
-		return false, fmt.Errorf("mismatching serverUrl (%s) and server_url (%s)", status.ServerUrl, c.Server)
+	if normalizeServerUrl(status.ServerUrl) != normalizeServerUrl(c.Server) { // NOTE: server is always known, so always check.
+		return false, fmt.Errorf("mismatching serverUrl (%s) and server (%s)", status.ServerUrl, c.Server)
 	}
 	if c.Email != "" && status.UserEmail != c.Email {
 		return false, fmt.Errorf("mismatching userEmail (%s) and email (%s)", status.UserEmail, c.Email)
@@ -195,6 +264,35 @@ func (c *Client) checkCorrectUser() (bool, error) {
 	return true, nil
 }
 
+func normalizeServerUrl(url string) string {
+	if url == "" { // NOTE: bw reports no serverUrl until one is configured.
+		url = defaultServer
+	}
+	return strings.TrimRight(url, "/")
+}
+
+func (c *Client) ensureServerConfigured() error {
+	// NOTE: the split urls can't be read back; they're only (re)configured when the server is.
+	status, err := c.bwStatus()
+	if err != nil {
+		return err
+	}
+	if normalizeServerUrl(status.ServerUrl) == normalizeServerUrl(c.Server) && (status.Status != "unauthenticated" || len(c.serverUrls) == 0) {
+		return nil
+	}
+	err = c.ensureLoggedOut()
+	if err != nil {
+		return err
+	}
+	c.mutexAuth.Lock()
+	defer c.mutexAuth.Unlock()
+	err = c.bwConfigServer()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *Client) ensureLoggedInAsCorrectUser() error {
 	alreadyLoggedIn, err := c.bwLoginCheck()
 	if err != nil {
@@ -315,11 +413,39 @@ func (c *Client) runAndCheckSucceeded(cmd *exec.Cmd, friendlyName string, ignore
 	return (*response).Success, nil // TODO conversion needed?
 }
 
+var errNotFound = errors.New("not found")
+
+func isNotFound(output []byte) bool {
+	outputString := string(output)
+	jsonStartIndex := strings.Index(outputString, "{")
+	if jsonStartIndex < 0 {
+		return false
+	}
+	var response Response
+	if err := json.Unmarshal([]byte(outputString[jsonStartIndex:]), &response); err != nil {
+		return false
+	}
+	return !response.Success && response.Message == "Not found."
+}
+
 func (c *Client) runOnly(cmd *exec.Cmd, friendlyName string, ignoreCode int) (*[]byte, error) {
 	// NOTE: ignoreCode can be 0; so it doesn't ignore any errors.
+	if !isCliSetupCommand(cmd) {
+		err := c.ensureCliSetUp()
+		if err != nil {
+			return nil, err
+		}
+	}
+	// NOTE: the session is only read here; after the setup that sets it, rather than when the args are built. It goes in the env so it isn't in ps either.
+	if c.SessionKey != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("BW_SESSION=%s", c.SessionKey))
+	}
 	output, err := cmd.CombinedOutput()
 	if err != nil { // TODO combine these if statements?
 		if exitError, ok := err.(*exec.ExitError); !ok || exitError.ExitCode() != ignoreCode {
+			if isNotFound(output) {
+				return &output, fmt.Errorf("cannot %s: %w", friendlyName, errNotFound)
+			}
 			return &output, fmt.Errorf("cannot %s: %s\nexit code: %s", friendlyName, string(output), err)
 		}
 	}
@@ -0,0 +1,118 @@
+package bitwarden
+
+import (
+	"fmt"
+)
+
+func (c *Client) bwListOrganizations() (*[]interface{}, error) {
+	cmd := c.command("list", "organizations", "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "list organizations", nil)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := (*data)["data"].([]interface{}); ok {
+		return &list, nil
+	} else {
+		return nil, fmt.Errorf("unexpected bwListOrganizations output:\n%v", data)
+	}
+}
+
+func (c *Client) bwListOrgMembers(organizationId string) (*[]interface{}, error) {
+	cmd := c.command("list", "org-members", "--organizationid", organizationId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "list org-members", &map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": []map[string]interface{}{
+				{
+					"twoFactorEnabled": "two_factor_enabled",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := (*data)["data"].([]interface{}); ok {
+		return &list, nil
+	} else {
+		return nil, fmt.Errorf("unexpected bwListOrgMembers output:\n%v", data)
+	}
+}
+
+var orgCollectionKeyConversion = map[string]interface{}{
+	"organizationId": "organization_id",
+	"externalId":     "external_id",
+	"groups": []map[string]interface{}{
+		{
+			"readOnly":      "read_only",
+			"hidePasswords": "hide_passwords",
+		},
+	},
+}
+
+func (c *Client) bwGetOrgCollection(organizationId string, id string) (*map[string]interface{}, error) {
+	cmd := c.command("get", "org-collection", id, "--organizationid", organizationId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "get org-collection", &map[string]interface{}{
+		"data": orgCollectionKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwListOrgCollections(organizationId string, filters ...string) (*[]interface{}, error) {
+	args := append([]string{"list", "org-collections", "--organizationid", organizationId, "--response"}, filters...)
+	cmd := c.command(args...)
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "list org-collections", &map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": []map[string]interface{}{orgCollectionKeyConversion},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := (*data)["data"].([]interface{}); ok {
+		return &list, nil
+	} else {
+		return nil, fmt.Errorf("unexpected bwListOrgCollections output:\n%v", data)
+	}
+}
+
+func (c *Client) bwCreateOrgCollection(organizationId string, collection *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedCollection, err := encodeObject(collection, &orgCollectionKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("create", "org-collection", encodedCollection, "--organizationid", organizationId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "create org-collection", &map[string]interface{}{
+		"data": orgCollectionKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwEditOrgCollection(organizationId string, id string, collection *map[string]interface{}) (*map[string]interface{}, error) {
+	encodedCollection, err := encodeObject(collection, &orgCollectionKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("edit", "org-collection", id, encodedCollection, "--organizationid", organizationId, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "edit org-collection", &map[string]interface{}{
+		"data": orgCollectionKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwDeleteOrgCollection(organizationId string, id string) error {
+	cmd := c.command("delete", "org-collection", id, "--organizationid", organizationId, "--response")
+	_, err := c.runGivingPasswordExpectingSuccess(cmd, "delete org-collection", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package bitwarden
+
+var sendKeyConversion = map[string]interface{}{
+	"accessId":       "access_id",
+	"accessUrl":      "access_url",
+	"maxAccessCount": "max_access_count",
+	"accessCount":    "access_count",
+	"revisionDate":   "revision_date",
+	"deletionDate":   "deletion_date",
+	"expirationDate": "expiration_date",
+	"passwordSet":    "password_set",
+	"hideEmail":      "hide_email",
+	"file": map[string]interface{}{
+		"fileName": "file_name",
+		"sizeName": "size_name",
+	},
+}
+
+func (c *Client) bwGetSend(id string) (*map[string]interface{}, error) {
+	err := c.requireCliFeature(cliFeatureSend)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("send", "get", id, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "send get", &map[string]interface{}{
+		"data": sendKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwCreateSend(send *map[string]interface{}) (*map[string]interface{}, error) {
+	// NOTE: for file sends, file.file_name is the path of the file to upload.
+	err := c.requireCliFeature(cliFeatureSend)
+	if err != nil {
+		return nil, err
+	}
+	encodedSend, err := encodeObject(send, &sendKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("send", "create", encodedSend, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "send create", &map[string]interface{}{
+		"data": sendKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwEditSend(send *map[string]interface{}) (*map[string]interface{}, error) {
+	// NOTE: the id is taken from the send itself.
+	err := c.requireCliFeature(cliFeatureSend)
+	if err != nil {
+		return nil, err
+	}
+	encodedSend, err := encodeObject(send, &sendKeyConversion)
+	if err != nil {
+		return nil, err
+	}
+	cmd := c.command("send", "edit", encodedSend, "--response")
+	data, err := c.runGivingPasswordExpectingSuccess(cmd, "send edit", &map[string]interface{}{
+		"data": sendKeyConversion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) bwRemoveSendPassword(id string) error {
+	err := c.requireCliFeature(cliFeatureSend)
+	if err != nil {
+		return err
+	}
+	cmd := c.command("send", "remove-password", id, "--response")
+	_, err = c.runGivingPasswordExpectingSuccess(cmd, "send remove-password", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) bwDeleteSend(id string) error {
+	err := c.requireCliFeature(cliFeatureSend)
+	if err != nil {
+		return err
+	}
+	cmd := c.command("send", "delete", id, "--response")
+	_, err = c.runGivingPasswordExpectingSuccess(cmd, "send delete", nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package bitwarden
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cliVersion is a bw version; both the old semver (e.g. 1.22.1) and the newer calendar (e.g. 2022.10.0) ones.
+type cliVersion [3]int
+
+// NOTE: bw status (used to check the logged in user) came with 1.14.0.
+var minimumCliVersion = cliVersion{1, 14, 0}
+
+// maximumCliVersion is the first version that isn't known to work; newer ones only give a warning.
+var maximumCliVersion = cliVersion{2023, 0, 0}
+
+const (
+	cliFeatureSend         = "send"
+	cliFeatureKeyConnector = "config server --key-connector"
+)
+
+// cliFeatureVersions are the versions that introduced the features only used when configured; so older versions still work without them.
+// NOTE: only features newer than minimumCliVersion belong here; e.g. login --apikey (1.12.0) is always there.
+var cliFeatureVersions = map[string]cliVersion{
+	cliFeatureSend:         {1, 15, 0},
+	cliFeatureKeyConnector: {1, 19, 0},
+}
+
+func parseCliVersion(output string) (cliVersion, error) {
+	// NOTE: node can print warnings before the version; so the last line is used.
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	last := strings.TrimPrefix(strings.TrimSpace(lines[len(lines)-1]), "v")
+	var version cliVersion
+	parts := strings.SplitN(last, ".", 3)
+	if len(parts) != 3 {
+		return version, fmt.Errorf("unexpected bw --version output:\n%s", output)
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(strings.SplitN(part, "-", 2)[0]) // NOTE: drops pre-release suffixes; e.g. 1.2.3-beta.
+		if err != nil {
+			return version, fmt.Errorf("unexpected bw --version output:\n%s", output)
+		}
+		version[i] = number
+	}
+	return version, nil
+}
+
+func (v cliVersion) lessThan(other cliVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+func (v cliVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+func (c *Client) checkCliVersion() error {
+	output, err := c.bwVersion()
+	if err != nil {
+		return err
+	}
+	version, err := parseCliVersion(output)
+	if err != nil {
+		return err
+	}
+	if version.lessThan(minimumCliVersion) {
+		return fmt.Errorf("bw (Bitwarden CLI) %s at %s is too old; at least %s is needed", version, c.BitwardenCLIBinary, minimumCliVersion)
+	}
+	c.cliVersion = version
+	return nil
+}
+
+// requireCliFeature checks that the installed bw has feature; setting bw up first, since that's what finds its version.
+func (c *Client) requireCliFeature(feature string) error {
+	err := c.ensureCliSetUp()
+	if err != nil {
+		return err
+	}
+	return c.checkCliFeature(feature)
+}
+
+// checkCliFeature is requireCliFeature for the setup itself; once checkCliVersion has run.
+func (c *Client) checkCliFeature(feature string) error {
+	if required, ok := cliFeatureVersions[feature]; ok && c.cliVersion.lessThan(required) {
+		return fmt.Errorf("bw (Bitwarden CLI) %s at %s doesn't support %s; at least %s is needed", c.cliVersion, c.BitwardenCLIBinary, feature, required)
+	}
+	return nil
+}
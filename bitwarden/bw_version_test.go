@@ -0,0 +1,82 @@
+package bitwarden
+
+import (
+	"testing"
+)
+
+func TestParseCliVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  cliVersion
+		expectErr bool
+	}{
+		{name: "semver", output: "1.22.1\n", expected: cliVersion{1, 22, 1}},
+		{name: "calendar", output: "2023.1.0", expected: cliVersion{2023, 1, 0}},
+		{name: "v prefix", output: "v1.14.0", expected: cliVersion{1, 14, 0}},
+		{name: "warnings before", output: "(node:1234) Warning: something is deprecated\n(Use `node --trace-warnings ...`)\n1.22.1\n", expected: cliVersion{1, 22, 1}},
+		{name: "version in a warning", output: "Update to 2023.1.0 is available\n1.22.1", expected: cliVersion{1, 22, 1}},
+		{name: "pre-release", output: "2022.10.0-beta.1", expected: cliVersion{2022, 10, 0}},
+		{name: "surrounding whitespace", output: "  \n 1.22.1 \n\n", expected: cliVersion{1, 22, 1}},
+		{name: "empty", output: "", expectErr: true},
+		{name: "two parts", output: "1.22", expectErr: true},
+		{name: "not a number", output: "1.x.0", expectErr: true},
+		{name: "warnings after", output: "1.22.1\n(node:1234) Warning: something is deprecated", expectErr: true},
+		{name: "error message", output: "command not found: bw", expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := parseCliVersion(test.output)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, version)
+			}
+		})
+	}
+}
+
+func TestCliVersionLessThan(t *testing.T) {
+	tests := []struct {
+		version  cliVersion
+		other    cliVersion
+		expected bool
+	}{
+		{cliVersion{1, 14, 0}, cliVersion{1, 14, 0}, false},
+		{cliVersion{1, 13, 9}, cliVersion{1, 14, 0}, true},
+		{cliVersion{1, 14, 1}, cliVersion{1, 14, 0}, false},
+		{cliVersion{1, 9, 0}, cliVersion{1, 10, 0}, true},
+		{cliVersion{1, 22, 1}, cliVersion{2022, 1, 0}, true},
+		{cliVersion{2022, 12, 0}, cliVersion{2023, 0, 0}, true},
+		{cliVersion{2023, 1, 0}, cliVersion{1, 22, 1}, false},
+	}
+	for _, test := range tests {
+		if lessThan := test.version.lessThan(test.other); lessThan != test.expected {
+			t.Errorf("%s < %s: expected %t, got %t", test.version, test.other, test.expected, lessThan)
+		}
+	}
+}
+
+func TestCheckCliFeature(t *testing.T) {
+	for version, expectErr := range map[cliVersion]bool{
+		{1, 14, 0}:   true,
+		{1, 15, 0}:   false,
+		{2023, 1, 0}: false,
+	} {
+		c := &Client{cliVersion: version, BitwardenCLIBinary: "bw"}
+		if err := c.checkCliFeature(cliFeatureSend); (err != nil) != expectErr {
+			t.Errorf("%s: expected an error %t, got %v", version, expectErr, err)
+		}
+	}
+	c := &Client{cliVersion: cliVersion{1, 14, 0}, BitwardenCLIBinary: "bw"}
+	if err := c.checkCliFeature("unknown feature"); err != nil {
+		t.Errorf("expected features without a known version to be allowed, got %s", err)
+	}
+}
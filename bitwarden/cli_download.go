@@ -0,0 +1,204 @@
+package bitwarden
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	defaultCliDownloadVersion = "1.22.1"
+	defaultCliDownloadMirror  = "https://github.com/bitwarden/cli/releases/download"
+)
+
+// cliReleaseChecksums are the known SHA-256 checksums of the release zips; keyed by version/platform (e.g. 1.22.1/linux).
+// Versions or platforms missing here need a user-supplied checksum.
+// TODO add the pinned release's checksums; from its bw-<platform>-sha256-<version>.txt.
+var cliReleaseChecksums = map[string]string{}
+
+// CLIDownloadConfig holds the settings for fetching bw instead of using the host's.
+type CLIDownloadConfig struct {
+	Version   string
+	MirrorUrl string // NOTE: releases are fetched from <mirror>/v<version>/bw-<platform>-<version>.zip; the layout of the GitHub releases.
+	Sha256    string // NOTE: of the zip; defaults to the one in cliReleaseChecksums.
+	CacheDir  string
+}
+
+func cliPlatform() (string, error) {
+	// NOTE: releases are only built for x64; arm64 macs run them with rosetta.
+	switch runtime.GOOS {
+	case "linux":
+		return "linux", nil
+	case "darwin":
+		return "macos", nil
+	case "windows":
+		return "windows", nil
+	}
+	return "", fmt.Errorf("no bw (Bitwarden CLI) release for %s", runtime.GOOS)
+}
+
+func cliCacheDir(config *CLIDownloadConfig) (string, error) {
+	if config.CacheDir != "" {
+		return config.CacheDir, nil
+	}
+	if pluginCacheDir := os.Getenv("TF_PLUGIN_CACHE_DIR"); pluginCacheDir != "" {
+		return filepath.Join(pluginCacheDir, "bitwarden-cli"), nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find a cache dir for bw; set cli_download.cache_dir: %s", err)
+	}
+	return filepath.Join(userCacheDir, "terraform-provider-bitwarden", "bitwarden-cli"), nil
+}
+
+// downloadBitwardenCLI gives the path of the configured bw release; fetching it into the cache dir when it isn't there yet.
+// NOTE: the download trusts the same CA certs as bw does; see newHttpClient.
+func downloadBitwardenCLI(config *CLIDownloadConfig, caCertFile string, insecureSkipVerify bool) (string, error) {
+	platform, err := cliPlatform()
+	if err != nil {
+		return "", err
+	}
+	checksum := strings.ToLower(config.Sha256)
+	if checksum == "" {
+		checksum = cliReleaseChecksums[fmt.Sprintf("%s/%s", config.Version, platform)]
+	}
+	if checksum == "" {
+		return "", fmt.Errorf("no known checksum for bw (Bitwarden CLI) %s on %s; set cli_download.sha256", config.Version, platform)
+	}
+	cacheDir, err := cliCacheDir(config)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, config.Version, platform)
+	binName := "bw"
+	if platform == "windows" {
+		binName = "bw.exe"
+	}
+	bin := filepath.Join(dir, binName)
+	// NOTE: the checksums of the zip a cached binary came from, and of the binary itself, are kept next to it; as <zip>\n<binary>.
+	// So changing sha256 fetches it again, and a cached binary that was changed since isn't run.
+	checksumFile := bin + ".sha256"
+	if cached, err := ioutil.ReadFile(checksumFile); err == nil {
+		sums := strings.Split(strings.TrimSpace(string(cached)), "\n")
+		if len(sums) == 2 && sums[0] == checksum {
+			if binChecksum, err := fileSha256(bin); err == nil && binChecksum == sums[1] {
+				return bin, nil
+			}
+		}
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/v%s/bw-%s-%s.zip", strings.TrimRight(config.MirrorUrl, "/"), config.Version, platform, config.Version)
+	httpClient, err := newHttpClient(caCertFile, insecureSkipVerify)
+	if err != nil {
+		return "", err
+	}
+	zipPath, err := downloadVerified(httpClient, url, dir, checksum)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(zipPath)
+	binChecksum, err := extractFromZip(zipPath, binName, bin)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(checksumFile, []byte(fmt.Sprintf("%s\n%s\n", checksum, binChecksum)), 0644)
+	if err != nil {
+		return "", err
+	}
+	return bin, nil
+}
+
+// downloadVerified fetches url into a temporary file in dir; and gives its path once its SHA-256 matches checksum.
+func downloadVerified(httpClient *http.Client, url string, dir string, checksum string) (string, error) {
+	response, err := httpClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("cannot download bw (Bitwarden CLI): %s", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download bw (Bitwarden CLI) from %s: %s", url, response.Status)
+	}
+
+	file, err := ioutil.TempFile(dir, "download-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), response.Body)
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("cannot download bw (Bitwarden CLI) from %s: %s", url, err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, checksum, actual)
+	}
+	return file.Name(), nil
+}
+
+// extractFromZip writes the file named name in the zip to dest, and gives its SHA-256; through a temporary file, so other processes never run a partial binary.
+func extractFromZip(zipPath string, name string, dest string) (string, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	for _, entry := range reader.File {
+		if entry.Name != name {
+			continue
+		}
+		src, err := entry.Open()
+		if err != nil {
+			return "", err
+		}
+		defer src.Close()
+		file, err := ioutil.TempFile(filepath.Dir(dest), "extract-*")
+		if err != nil {
+			return "", err
+		}
+		hash := sha256.New()
+		_, err = io.Copy(io.MultiWriter(file, hash), src)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(file.Name(), 0755)
+		}
+		if err == nil {
+			err = os.Rename(file.Name(), dest)
+		}
+		if err != nil {
+			os.Remove(file.Name())
+			return "", err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+	return "", fmt.Errorf("no %s in %s", name, zipPath)
+}
+
+func fileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,182 @@
+package bitwarden
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testCliBinary = "#!/bin/sh\necho 1.22.1\n"
+
+// testCliMirror serves a release zip with a fake bw, laid out like the GitHub releases; and counts the downloads.
+type testCliMirror struct {
+	server    *httptest.Server
+	zip       []byte
+	mutex     sync.Mutex
+	downloads int
+}
+
+func newTestCliMirror(t *testing.T) *testCliMirror {
+	platform, err := cliPlatform()
+	if err != nil {
+		t.Skip(err)
+	}
+	binName := "bw"
+	if platform == "windows" {
+		binName = "bw.exe"
+	}
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	file, err := writer.Create(binName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte(testCliBinary))
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mirror := &testCliMirror{zip: buf.Bytes()}
+	mirror.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/v1.22.1/bw-%s-1.22.1.zip", platform) {
+			http.NotFound(w, r)
+			return
+		}
+		mirror.mutex.Lock()
+		mirror.downloads++
+		mirror.mutex.Unlock()
+		w.Write(mirror.zip)
+	}))
+	t.Cleanup(mirror.server.Close)
+	return mirror
+}
+
+func (m *testCliMirror) downloadCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.downloads
+}
+
+func (m *testCliMirror) checksum() string {
+	sum := sha256.Sum256(m.zip)
+	return hex.EncodeToString(sum[:])
+}
+
+func (m *testCliMirror) caCertFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.server.Certificate().Raw}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func (m *testCliMirror) config(t *testing.T, checksum string) *CLIDownloadConfig {
+	return &CLIDownloadConfig{Version: "1.22.1", MirrorUrl: m.server.URL + "/", Sha256: checksum, CacheDir: t.TempDir()}
+}
+
+func TestDownloadBitwardenCLI(t *testing.T) {
+	mirror := newTestCliMirror(t)
+	config := mirror.config(t, strings.ToUpper(mirror.checksum()))
+
+	bin, err := downloadBitwardenCLI(config, mirror.caCertFile(t), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != testCliBinary {
+		t.Errorf("unexpected binary %q", content)
+	}
+	if info, err := os.Stat(bin); err != nil || info.Mode()&0100 == 0 {
+		t.Errorf("binary isn't executable: %v", err)
+	}
+}
+
+func TestDownloadBitwardenCLIChecksumMismatch(t *testing.T) {
+	mirror := newTestCliMirror(t)
+	config := mirror.config(t, strings.Repeat("0", 64))
+
+	_, err := downloadBitwardenCLI(config, "", true)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	var leftovers []string
+	filepath.Walk(config.CacheDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			leftovers = append(leftovers, path)
+		}
+		return nil
+	})
+	if len(leftovers) > 0 {
+		t.Errorf("expected nothing to be kept in the cache dir, got %v", leftovers)
+	}
+}
+
+func TestDownloadBitwardenCLIUntrusted(t *testing.T) {
+	mirror := newTestCliMirror(t)
+
+	_, err := downloadBitwardenCLI(mirror.config(t, mirror.checksum()), "", false)
+	if err == nil {
+		t.Fatal("expected the mirror's self-signed certificate to be declined")
+	}
+	if mirror.downloadCount() != 0 {
+		t.Errorf("expected no downloads, got %d", mirror.downloadCount())
+	}
+}
+
+func TestDownloadBitwardenCLICache(t *testing.T) {
+	mirror := newTestCliMirror(t)
+	config := mirror.config(t, mirror.checksum())
+
+	bin, err := downloadBitwardenCLI(config, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := downloadBitwardenCLI(config, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != bin || mirror.downloadCount() != 1 {
+		t.Fatalf("expected the cached binary to be reused; got %s after %d downloads", again, mirror.downloadCount())
+	}
+
+	err = ioutil.WriteFile(bin, []byte("#!/bin/sh\necho tampered\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = downloadBitwardenCLI(config, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mirror.downloadCount() != 2 {
+		t.Fatalf("expected a tampered binary to be downloaded again; got %d downloads", mirror.downloadCount())
+	}
+	content, err := ioutil.ReadFile(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != testCliBinary {
+		t.Errorf("expected the tampered binary to be replaced, got %q", content)
+	}
+}
+
+func TestDownloadBitwardenCLIUnknownChecksum(t *testing.T) {
+	_, err := downloadBitwardenCLI(&CLIDownloadConfig{Version: "0.0.1", MirrorUrl: "https://mirror.invalid", CacheDir: t.TempDir()}, "", false)
+	if err == nil || !strings.Contains(err.Error(), "set cli_download.sha256") {
+		t.Fatalf("expected an unknown checksum error, got %v", err)
+	}
+}
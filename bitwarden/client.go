@@ -1,61 +1,300 @@
 package bitwarden
 
 import (
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"os/exec"
 )
 
-type Client struct { // TODO need to isolate this from environment vars so it doesn't use host login context.
+type Client struct {
 	// TODO is 2fa required again for unlock or sync?
 	// TODO default cmd has --nointeraction
 	userId             string
 	clientId           string
 	clientSecret       string
+	twoStepMethod      int
+	twoStepCode        string
+	twoStepTotpSecret  string // NOTE: used to compute twoStepCode at login time, when that isn't given.
 	Email              string // TODO needed? if I separate the env, will I ever need to login after the first time?
 	MasterPassword     string
 	SessionKey         string
 	Server             string
+	serverUrls         map[string]string // NOTE: urls of the separately deployed services; keyed by their 'bw config server' flag. See serverUrlFlags.
 	BitwardenCLIBinary string
+	cliVersion         cliVersion // NOTE: unset until bw is set up; see ensureCliSetUp.
+	cliSetup           *sync.Once
+	cliSetupErr        error
+	vault              vault  // NOTE: reads items and folders; see backend.
+	DataDir            string // NOTE: the bw data dir (BITWARDENCLI_APPDATA_DIR) of this client; keeps its login separate from the host's and other clients'.
+	usesHostDataDir    bool   // NOTE: with just session_key; see NewClient.
+	caCertFile         string // NOTE: extra CA certs for the server's TLS; given to bw as NODE_EXTRA_CA_CERTS.
+	insecureSkipVerify bool
 	mutex              *sync.Mutex
 	mutexAuth          *sync.Mutex // Blocks changes to login/logout unlock/lock. See if I can adjust this so multiple simultaneous operations can run while each separately blocking auth changes
 }
 
-func NewClient(email string, masterPassword string, server string, clientId string, clientSecret string, userId string, sessionKey string) (*Client, error) {
-	bin, err := findHostBitwardenCLI()
+const defaultServer = "https://bitwarden.com"
+
+// serverUrlFlags are the 'bw config server' flags for the urls of separately deployed services.
+var serverUrlFlags = []string{"api", "identity", "web-vault", "icons", "notifications", "events", "key-connector"}
+
+var (
+	tempDataDirs      []string
+	tempDataDirsMutex sync.Mutex
+)
+
+// ClientConfig holds the provider settings a Client is made from.
+type ClientConfig struct {
+	Email              string
+	MasterPassword     string
+	Server             string
+	ServerUrls         map[string]string
+	ClientId           string
+	ClientSecret       string
+	UserId             string
+	SessionKey         string
+	DataDir            string
+	CLIPath            string             // NOTE: defaults to bw from PATH.
+	CLIDownload        *CLIDownloadConfig // NOTE: when set, bw is fetched instead; see downloadBitwardenCLI.
+	Backend            string             // NOTE: backendCli or backendApi; bw is still needed for writes either way.
+	TwoStepMethod      int
+	TwoStepCode        string
+	TwoStepTotpSecret  string
+	CACertFile         string
+	CACertPEM          string // NOTE: written to a file in the data dir; node only takes extra CA certs from a file.
+	InsecureSkipVerify bool
+}
+
+func NewClient(config *ClientConfig) (*Client, error) {
+	var err error
+	// NOTE: a session key only works with the bw login it came from. Without data_dir, that's the host's; so a config with
+	// just session_key keeps using the host's data dir, as before clients got their own. That login is never reconfigured.
+	dataDir := config.DataDir
+	usesHostDataDir := dataDir == "" && config.SessionKey != ""
+	switch {
+	case usesHostDataDir:
+		dataDir, err = hostDataDir()
+	case dataDir == "":
+		dataDir, err = newTempDataDir()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("bw (Bitwarden CLI) not found")
+		return nil, err
+	}
+	caCertDir := dataDir
+	if usesHostDataDir {
+		caCertDir, err = newTempDataDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	caCertFile, err := prepareCACertFile(config, caCertDir)
+	if err != nil {
+		return nil, err
+	}
+	var bin string
+	if config.CLIDownload != nil {
+		bin, err = downloadBitwardenCLI(config.CLIDownload, caCertFile, config.InsecureSkipVerify)
+	} else {
+		bin, err = findHostBitwardenCLI(config.CLIPath)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	bw := &Client{
-		userId:             userId,
-		clientId:           clientId,
-		clientSecret:       clientSecret,
-		Email:              email,
-		MasterPassword:     masterPassword,
-		SessionKey:         sessionKey,
-		Server:             server,
+		userId:             config.UserId,
+		clientId:           config.ClientId,
+		clientSecret:       config.ClientSecret,
+		twoStepMethod:      config.TwoStepMethod,
+		twoStepCode:        config.TwoStepCode,
+		twoStepTotpSecret:  config.TwoStepTotpSecret,
+		Email:              config.Email,
+		MasterPassword:     config.MasterPassword,
+		SessionKey:         config.SessionKey,
+		Server:             config.Server,
+		serverUrls:         config.ServerUrls,
 		BitwardenCLIBinary: bin,
+		DataDir:            dataDir,
+		usesHostDataDir:    usesHostDataDir,
+		caCertFile:         caCertFile,
+		insecureSkipVerify: config.InsecureSkipVerify,
+		cliSetup:           &sync.Once{},
 		mutex:              &sync.Mutex{},
 		mutexAuth:          &sync.Mutex{},
 	}
-	err = bw.ensureUnlocked()
-	if err != nil {
-		return bw, err
+	if config.Backend == backendApi {
+		// NOTE: bw is only set up once a write, or a filter the api can't do, first needs it; so plans that only read never start it.
+		bw.vault, err = newApiVault(bw)
+		if err != nil {
+			return bw, err
+		}
+		return bw, nil
 	}
-	err = bw.bwSync()
+	err = bw.ensureCliSetUp()
 	if err != nil {
 		return bw, err
 	}
+	bw.vault = &cliVault{c: bw}
 	return bw, nil
 }
 
-func findHostBitwardenCLI() (string, error) {
-	// TODO constrain version
-	o, err := exec.Command("bw", "--version").Output()
+// ensureCliSetUp sets bw up on its first use; checking its version, configuring the server, and unlocking and syncing the vault.
+// The result is kept; so a failed setup fails every later command the same way.
+func (c *Client) ensureCliSetUp() error {
+	c.cliSetup.Do(func() {
+		c.cliSetupErr = c.setUpCli()
+	})
+	return c.cliSetupErr
+}
+
+func (c *Client) setUpCli() error {
+	err := c.checkCliVersion()
+	if err != nil {
+		return err
+	}
+	if !c.usesHostDataDir { // NOTE: checkCorrectUser reports a mismatching server instead.
+		err = c.ensureServerConfigured()
+		if err != nil {
+			return err
+		}
+	}
+	err = c.ensureUnlocked()
+	if err != nil {
+		return err
+	}
+	return c.bwSync()
+}
+
+// cliSetupCommands are the bw commands used by setUpCli; they run before the setup is done, so they don't wait on it.
+var cliSetupCommands = map[string]bool{
+	"--version": true,
+	"status":    true,
+	"config":    true,
+	"login":     true,
+	"logout":    true,
+	"unlock":    true,
+	"lock":      true,
+	"sync":      true,
+}
+
+func isCliSetupCommand(cmd *exec.Cmd) bool {
+	return len(cmd.Args) > 1 && cliSetupCommands[cmd.Args[1]]
+}
+
+// command prepares a bw command that runs in the client's own data dir.
+func (c *Client) command(args ...string) *exec.Cmd {
+	cmd := exec.Command(c.BitwardenCLIBinary, args...)
+	cmd.Env = c.environment()
+	return cmd
+}
+
+func (c *Client) environment() []string {
+	// NOTE: inherited BW_* vars (e.g. the host's BW_SESSION) are dropped, so another login context can't leak in.
+	// The host's TLS settings for node are kept, unless the provider sets its own.
+	var env []string
+	for _, variable := range os.Environ() {
+		if strings.HasPrefix(variable, "BW_") || strings.HasPrefix(variable, "BITWARDENCLI_") {
+			continue
+		}
+		if c.caCertFile != "" && strings.HasPrefix(variable, "NODE_EXTRA_CA_CERTS=") {
+			continue
+		}
+		if c.insecureSkipVerify && strings.HasPrefix(variable, "NODE_TLS_REJECT_UNAUTHORIZED=") {
+			continue
+		}
+		env = append(env, variable)
+	}
+	env = append(env, fmt.Sprintf("BITWARDENCLI_APPDATA_DIR=%s", c.DataDir))
+	if c.caCertFile != "" {
+		env = append(env, fmt.Sprintf("NODE_EXTRA_CA_CERTS=%s", c.caCertFile))
+	}
+	if c.insecureSkipVerify {
+		env = append(env, "NODE_TLS_REJECT_UNAUTHORIZED=0")
+	}
+	return env
+}
+
+// prepareCACertFile checks the configured CA certs, and gives the path of the file holding them; if any.
+func prepareCACertFile(config *ClientConfig, dataDir string) (string, error) {
+	switch {
+	case config.CACertPEM != "":
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return "", fmt.Errorf("ca_cert_pem has no valid PEM certificates")
+		}
+		path := filepath.Join(dataDir, "ca-certs.pem")
+		err := ioutil.WriteFile(path, []byte(config.CACertPEM), 0600)
+		if err != nil {
+			return "", fmt.Errorf("cannot write ca_cert_pem to %s: %s", path, err)
+		}
+		return path, nil
+	case config.CACertFile != "":
+		pem, err := ioutil.ReadFile(config.CACertFile)
+		if err != nil {
+			return "", fmt.Errorf("cannot read ca_cert_file: %s", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+			return "", fmt.Errorf("ca_cert_file %s has no valid PEM certificates", config.CACertFile)
+		}
+		path, err := filepath.Abs(config.CACertFile) // NOTE: bw may not run from the same working dir.
+		if err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+	return "", nil
+}
+
+// hostDataDir is where bw keeps its data when BITWARDENCLI_APPDATA_DIR isn't set; <user config dir>/Bitwarden CLI.
+func hostDataDir() (string, error) {
+	if dir := os.Getenv("BITWARDENCLI_APPDATA_DIR"); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find the host's bw data dir; set data_dir: %s", err)
+	}
+	return filepath.Join(configDir, "Bitwarden CLI"), nil
+}
+
+func newTempDataDir() (string, error) {
+	dir, err := ioutil.TempDir("", "terraform-provider-bitwarden")
+	if err != nil {
+		return "", err
+	}
+	tempDataDirsMutex.Lock()
+	defer tempDataDirsMutex.Unlock()
+	tempDataDirs = append(tempDataDirs, dir)
+	return dir, nil
+}
+
+// Cleanup removes the temporary data dirs of the clients; call it once the provider stops serving.
+func Cleanup() {
+	tempDataDirsMutex.Lock()
+	defer tempDataDirsMutex.Unlock()
+	for _, dir := range tempDataDirs {
+		os.RemoveAll(dir)
+	}
+	tempDataDirs = nil
+}
+
+func findHostBitwardenCLI(cliPath string) (string, error) {
+	// NOTE: the version is checked once the client is set up; see checkCliVersion.
+	if cliPath == "" {
+		bin, err := exec.LookPath("bw")
+		if err != nil {
+			return "", fmt.Errorf("bw (Bitwarden CLI) not found in PATH; install it or set cli_path")
+		}
+		return bin, nil
+	}
+	bin, err := exec.LookPath(cliPath)
 	if err != nil {
-		return "", fmt.Errorf("Error from bw:\n%s", o)
+		return "", fmt.Errorf("bw (Bitwarden CLI) not found at cli_path %s: %s", cliPath, err)
 	}
-	return "bw", nil
+	return bin, nil
 }
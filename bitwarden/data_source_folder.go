@@ -0,0 +1,56 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFolder() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFolderRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	name := d.Get("name").(string)
+	folders, err := c.vault.listFolders("--search", name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var matches []string
+	for _, folder := range *folders {
+		folderAsMap, ok := folder.(map[string]interface{})
+		if !ok {
+			return diag.FromErr(fmt.Errorf("unexpected folder format:\n%v", folder))
+		}
+		id, ok := folderAsMap["id"].(string) // NOTE: the "No Folder" pseudo folder has no id.
+		if !ok || folderAsMap["name"] != name {
+			continue
+		}
+		matches = append(matches, id)
+	}
+
+	switch len(matches) {
+	case 1:
+		d.SetId(matches[0])
+	case 0:
+		return diag.FromErr(fmt.Errorf("no folder named %q", name))
+	default:
+		return diag.FromErr(fmt.Errorf("%d folders are named %q:\n  - %s", len(matches), name, strings.Join(matches, "\n  - ")))
+	}
+
+	return diags
+}
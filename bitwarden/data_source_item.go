@@ -4,298 +4,251 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// dataSourceItemItemsDeprecation is for configs from before bitwarden_item looked up a single item; when it listed them all.
+const dataSourceItemItemsDeprecation = "bitwarden_item lists all items when none of id, name or search is set; this will be removed in the next release. Use the bitwarden_items data source instead."
+
 func dataSourceItem() *schema.Resource {
-	// Using weird workarounds until this is resolved: https://github.com/hashicorp/terraform-plugin-sdk/issues/616
-	return &schema.Resource{
-		ReadContext: dataSourceItemRead,
-		Schema: map[string]*schema.Schema{
-			"items": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"object": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"organization_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"folder_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"type": {
-							Type:     schema.TypeInt,
-							Computed: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"notes": {
-							Type:      schema.TypeString,
-							Computed:  true,
-							Sensitive: true,
-						},
-						"favorite": {
-							Type:     schema.TypeBool,
-							Computed: true,
-						},
-						"fields": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"type": {
-										Type:     schema.TypeInt,
-										Computed: true,
-									},
-									"value": { // NOTE this is a string even when it's a bool field.
-										Type:      schema.TypeString,
-										Computed:  true,
-										Sensitive: true,
-									},
-								},
-							},
-						},
-						"secure_note": {
-							Type:     schema.TypeList,
-							Computed: true,
-							//MinItems: 1,
-							//MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:     schema.TypeInt,
-										Computed: true,
-									},
-								},
-							},
-						},
-						"identity": {
-							Type:     schema.TypeList,
-							Computed: true,
-							//MinItems: 1,
-							//MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"title": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"first_name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"middle_name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"last_name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"address1": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"address2": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"address3": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"city": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"state": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"postal_code": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"country": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"company": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"email": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"phone": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"ssn": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"username": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"passport_number": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"license_number": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-								},
-							},
-						},
-						"card": {
-							Type:     schema.TypeList,
-							Computed: true,
-							//MinItems: 1,
-							//MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"cardholder_name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"brand": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"number": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"exp_month": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"exp_year": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"code": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-								},
-							},
-						},
-						"login": {
-							Type:     schema.TypeList,
-							Computed: true,
-							//MinItems: 1,
-							//MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"uris": {
-										Type:     schema.TypeList,
-										Computed: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"match": {
-													Type:     schema.TypeInt, // TODO sometimes null
-													Computed: true,
-												},
-												"uri": {
-													Type:     schema.TypeString,
-													Computed: true,
-												},
-											},
-										},
-									},
-									"username": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"password": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"totp": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"password_revision_date": {
-										Type:     schema.TypeString, // TODO sometimes null
-										Computed: true,
-									},
-								},
-							},
-						},
-						"collection_ids": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString, // TODO unknown type/format
-							},
-						},
-						"revision_date": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+	// NOTE: at most one lookup; none is the deprecated listing, see dataSourceItemItemsDeprecation. It'll be exactly one afterwards.
+	s := itemSchema()
+	s["id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"name", "search"},
+	}
+	s["name"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"id", "search"},
+	}
+	s["search"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"id", "name"},
+	}
+	s["items"] = &schema.Schema{
+		Type:       schema.TypeList,
+		Computed:   true,
+		Deprecated: dataSourceItemItemsDeprecation,
+		Elem: &schema.Resource{
+			Schema: itemSchema(),
+		},
+	}
+	s["folder_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"id"},
+	}
+	s["organization_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"id"},
+	}
+	s["collection_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"id"},
+	}
+	// NOTE: when several fields share a name, the first one (in the order bitwarden shows them) is used in fields_map and fields_by_type.
+	s["fields_map"] = &schema.Schema{
+		Type:      schema.TypeMap,
+		Computed:  true,
+		Sensitive: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	s["fields_by_type"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"text": { // NOTE: sensitive like the values in fields; a text field can hold a secret as well.
+					Type:      schema.TypeMap,
+					Computed:  true,
+					Sensitive: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"hidden": {
+					Type:      schema.TypeMap,
+					Computed:  true,
+					Sensitive: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"boolean": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeBool,
+					},
+				},
+				"linked": { // NOTE: name to linked_id.
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
 					},
 				},
 			},
 		},
 	}
+	return &schema.Resource{
+		ReadContext: dataSourceItemRead,
+		Schema:      s,
+	}
 }
 
-// TODO mark sensitive pieces.
-
 func dataSourceItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
 	var diags diag.Diagnostics
-	data, err := c.bwListItems()
+	_, byName := d.GetOk("name")
+	_, bySearch := d.GetOk("search")
+	if _, byId := d.GetOk("id"); !byId && !byName && !bySearch {
+		diags = dataSourceItemsRead(ctx, d, m)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Deprecated use of bitwarden_item",
+			Detail:   dataSourceItemItemsDeprecation,
+		})
+	}
+	var item *map[string]interface{}
+	var err error
+	if id, ok := d.GetOk("id"); ok {
+		item, err = c.vault.getItem(id.(string))
+	} else {
+		item, err = findItem(c, d)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	enclosure := &map[string]interface{}{
-		"real": *data,
+	fieldsMap, fieldsByType, err := flattenFieldMaps((*item)["fields"])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = setItemData(d, item, dataSourceItem().Schema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("fields_map", fieldsMap); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("fields_by_type", []interface{}{fieldsByType}); err != nil {
+		return diag.FromErr(err)
 	}
-	err = encloseMaps(enclosure, &map[string]interface{}{
-		"real": []map[string]interface{}{
-			{
-				"card":        "",
-				"identity":    "",
-				"login":       "",
-				"secure_note": "",
-			},
-		},
-	})
-	//return diag.FromErr(fmt.Errorf("%v\n\n%v", enclosure, (*enclosure)["real"]))
 
-	if err := d.Set("items", (*enclosure)["real"]); err != nil {
-		newErr := fmt.Errorf("%s\n\n%v", err.Error(), data)
-		return diag.FromErr(newErr)
+	return diags
+}
+
+func flattenFieldMaps(fields interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	fieldsMap := map[string]interface{}{}
+	byType := map[int]map[string]interface{}{
+		fieldTypeText:    {},
+		fieldTypeHidden:  {},
+		fieldTypeBoolean: {},
+		fieldTypeLinked:  {},
 	}
+	list, _ := fields.([]interface{})
+	for _, field := range list {
+		fieldAsMap, ok := field.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected field format:\n%v", field)
+		}
+		name, _ := fieldAsMap["name"].(string)
+		if _, duplicate := fieldsMap[name]; duplicate {
+			continue
+		}
+		value, _ := fieldAsMap["value"].(string)
+		fieldsMap[name] = value
 
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10)) // TODO use real ID for this.
+		fieldType, _ := fieldAsMap["type"].(float64)
+		switch int(fieldType) {
+		case fieldTypeBoolean:
+			parsed, err := strconv.ParseBool(value)
+			if value == "" {
+				parsed, err = false, nil
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot parse boolean field %s: %s", name, err)
+			}
+			byType[fieldTypeBoolean][name] = parsed
+		case fieldTypeLinked:
+			byType[fieldTypeLinked][name] = fieldAsMap["linked_id"]
+		case fieldTypeText, fieldTypeHidden:
+			byType[int(fieldType)][name] = value
+		}
+	}
+	return fieldsMap, map[string]interface{}{
+		"text":    byType[fieldTypeText],
+		"hidden":  byType[fieldTypeHidden],
+		"boolean": byType[fieldTypeBoolean],
+		"linked":  byType[fieldTypeLinked],
+	}, nil
+}
 
-	return diags
+func findItem(c *Client, d *schema.ResourceData) (*map[string]interface{}, error) {
+	// NOTE: name is matched exactly; search is handed to bw as is, so it also matches urls, usernames etc.
+	var filters []string
+	var lookup string
+	name, byName := d.GetOk("name")
+	if byName {
+		filters = append(filters, "--search", name.(string))
+		lookup = fmt.Sprintf("name %q", name)
+	} else {
+		search := d.Get("search").(string)
+		filters = append(filters, "--search", search)
+		lookup = fmt.Sprintf("search %q", search)
+	}
+	for _, filter := range [][2]string{
+		{"folder_id", "--folderid"},
+		{"collection_id", "--collectionid"},
+		{"organization_id", "--organizationid"},
+	} {
+		if value, ok := d.GetOk(filter[0]); ok {
+			filters = append(filters, filter[1], value.(string))
+			lookup = fmt.Sprintf("%s, %s %q", lookup, filter[0], value)
+		}
+	}
+
+	items, err := c.vault.listItems(filters...)
+	if err != nil {
+		return nil, err
+	}
+	var matches []map[string]interface{}
+	for _, item := range *items {
+		itemAsMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected item format:\n%v", item)
+		}
+		if byName && itemAsMap["name"] != name {
+			continue
+		}
+		matches = append(matches, itemAsMap)
+	}
+
+	switch len(matches) {
+	case 1:
+		return &matches[0], nil
+	case 0:
+		return nil, fmt.Errorf("no item matches %s", lookup)
+	default:
+		candidates := make([]string, len(matches))
+		for i, match := range matches {
+			candidates[i] = fmt.Sprintf("  - %v (id: %v)", match["name"], match["id"])
+		}
+		return nil, fmt.Errorf("%d items match %s; use id or add folder_id, collection_id or organization_id to pick one:\n%s", len(matches), lookup, strings.Join(candidates, "\n"))
+	}
 }
@@ -0,0 +1,78 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceItemAttachment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceItemAttachmentRead,
+		Schema: map[string]*schema.Schema{
+			"item_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"file_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content": { // NOTE: only useful for text; binary content is mangled. Use content_base64 for that.
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"content_base64": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceItemAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	itemId := d.Get("item_id").(string)
+	id := d.Get("id").(string)
+	item, err := c.vault.getItem(itemId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attachment, ok := findAttachment(item, id)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("item %s has no attachment %s", itemId, id))
+	}
+	content, err := c.bwGetAttachment(itemId, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for key, value := range map[string]interface{}{
+		"file_name":      attachment["file_name"],
+		"size":           attachment["size"],
+		"content":        string(*content),
+		"content_base64": base64.StdEncoding.EncodeToString(*content),
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("cannot set %s: %s", key, err))
+		}
+	}
+	d.SetId(id)
+
+	return diags
+}
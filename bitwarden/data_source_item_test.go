@@ -0,0 +1,142 @@
+package bitwarden
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFlattenFieldMaps(t *testing.T) {
+	field := func(name string, fieldType int, value interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "type": float64(fieldType), "value": value, "linked_id": nil}
+	}
+	linked := func(name string, linkedId float64) map[string]interface{} {
+		return map[string]interface{}{"name": name, "type": float64(fieldTypeLinked), "value": nil, "linked_id": linkedId}
+	}
+	empty := func() map[string]interface{} { return map[string]interface{}{} }
+
+	tests := []struct {
+		name           string
+		fields         interface{}
+		expectedMap    map[string]interface{}
+		expectedByType map[string]interface{}
+		expectErr      bool
+	}{
+		{
+			name:           "no fields",
+			fields:         nil,
+			expectedMap:    empty(),
+			expectedByType: map[string]interface{}{"text": empty(), "hidden": empty(), "boolean": empty(), "linked": empty()},
+		},
+		{
+			name: "by type",
+			fields: []interface{}{
+				field("user", fieldTypeText, "alice"),
+				field("token", fieldTypeHidden, "s3cret"),
+				field("admin", fieldTypeBoolean, "true"),
+				linked("login", 100),
+			},
+			expectedMap: map[string]interface{}{"user": "alice", "token": "s3cret", "admin": "true", "login": ""},
+			expectedByType: map[string]interface{}{
+				"text":    map[string]interface{}{"user": "alice"},
+				"hidden":  map[string]interface{}{"token": "s3cret"},
+				"boolean": map[string]interface{}{"admin": true},
+				"linked":  map[string]interface{}{"login": float64(100)},
+			},
+		},
+		{
+			name: "first one wins",
+			fields: []interface{}{
+				field("a", fieldTypeText, "first"),
+				field("a", fieldTypeText, "second"),
+				field("a", fieldTypeHidden, "third"),
+				field("b", fieldTypeHidden, "first"),
+				field("b", fieldTypeBoolean, "not a boolean"),
+			},
+			expectedMap: map[string]interface{}{"a": "first", "b": "first"},
+			expectedByType: map[string]interface{}{
+				"text":    map[string]interface{}{"a": "first"},
+				"hidden":  map[string]interface{}{"b": "first"},
+				"boolean": empty(),
+				"linked":  empty(),
+			},
+		},
+		{
+			name: "booleans",
+			fields: []interface{}{
+				field("yes", fieldTypeBoolean, "true"),
+				field("no", fieldTypeBoolean, "false"),
+				field("unset", fieldTypeBoolean, nil),
+				field("empty", fieldTypeBoolean, ""),
+			},
+			expectedMap: map[string]interface{}{"yes": "true", "no": "false", "unset": "", "empty": ""},
+			expectedByType: map[string]interface{}{
+				"text":    empty(),
+				"hidden":  empty(),
+				"boolean": map[string]interface{}{"yes": true, "no": false, "unset": false, "empty": false},
+				"linked":  empty(),
+			},
+		},
+		{
+			name:      "invalid boolean",
+			fields:    []interface{}{field("flag", fieldTypeBoolean, "yes please")},
+			expectErr: true,
+		},
+		{
+			name:      "unexpected field format",
+			fields:    []interface{}{"name"},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fieldsMap, byType, err := flattenFieldMaps(test.fields)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v %v", fieldsMap, byType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(fieldsMap, test.expectedMap) {
+				t.Errorf("fields_map: expected %v, got %v", test.expectedMap, fieldsMap)
+			}
+			if !reflect.DeepEqual(byType, test.expectedByType) {
+				t.Errorf("fields_by_type: expected %v, got %v", test.expectedByType, byType)
+			}
+		})
+	}
+}
+
+func TestDataSourceItemDeprecatedListing(t *testing.T) {
+	c := &Client{vault: &testVault{items: []interface{}{
+		map[string]interface{}{"object": "item", "id": "n1", "type": float64(2), "name": "first", "secure_note": map[string]interface{}{"type": float64(0)}},
+		map[string]interface{}{"object": "item", "id": "n2", "type": float64(2), "name": "second", "secure_note": map[string]interface{}{"type": float64(0)}},
+	}}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceItem().Schema, map[string]interface{}{})
+	diags := dataSourceItemRead(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a deprecation warning, got %v", diags)
+	}
+	if items := d.Get("items").([]interface{}); len(items) != 2 {
+		t.Errorf("expected all items, got %v", items)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceItem().Schema, map[string]interface{}{"name": "second"})
+	diags = dataSourceItemRead(context.Background(), d, c)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	if d.Id() != "n2" || len(d.Get("items").([]interface{})) != 0 {
+		t.Errorf("expected only the named item, got %s and %v", d.Id(), d.Get("items"))
+	}
+}
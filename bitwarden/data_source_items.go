@@ -0,0 +1,54 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceItems() *schema.Resource {
+	// Using weird workarounds until this is resolved: https://github.com/hashicorp/terraform-plugin-sdk/issues/616
+	return &schema.Resource{
+		ReadContext: dataSourceItemsRead,
+		Schema: map[string]*schema.Schema{
+			"items": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: itemSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceItemsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	data, err := c.vault.listItems()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	enclosure := &map[string]interface{}{
+		"real": *data,
+	}
+	err = encloseMaps(enclosure, &map[string]interface{}{
+		"real": []map[string]interface{}{itemMapsToEnclose},
+	})
+	//return diag.FromErr(fmt.Errorf("%v\n\n%v", enclosure, (*enclosure)["real"]))
+
+	if err := d.Set("items", (*enclosure)["real"]); err != nil {
+		newErr := fmt.Errorf("%s\n\n%v", err.Error(), data)
+		return diag.FromErr(newErr)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10)) // TODO use real ID for this.
+
+	return diags
+}
@@ -0,0 +1,110 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLoginItems() *schema.Resource {
+	// NOTE: matching is left to 'bw list items --url'; it applies each stored uri's match type (or the default one when null),
+	// and the equivalent domains synced from the server, which aren't available any other way.
+	return &schema.Resource{
+		ReadContext: dataSourceLoginItemsRead,
+		Schema: map[string]*schema.Schema{
+			"uri": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: loginItemsSchema(),
+				},
+			},
+		},
+	}
+}
+
+// loginItemsSchema is itemSchema with the uri match types by name, like bitwarden_item_login has them; so the default isn't mistaken for domain (0).
+func loginItemsSchema() map[string]*schema.Schema {
+	s := itemSchema()
+	login := s["login"].Elem.(*schema.Resource).Schema
+	uris := login["uris"].Elem.(*schema.Resource).Schema
+	uris["match"] = &schema.Schema{ // NOTE: one of uriMatchTypes; "" for the default.
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return s
+}
+
+// nameUriMatchTypes replaces the uri match types of a login item with their names; see uriMatchName.
+func nameUriMatchTypes(item map[string]interface{}) {
+	login, ok := item["login"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	uris, ok := login["uris"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, uri := range uris {
+		if uriAsMap, ok := uri.(map[string]interface{}); ok {
+			uriAsMap["match"] = uriMatchName(uriAsMap["match"])
+		}
+	}
+}
+
+func dataSourceLoginItemsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	uri := d.Get("uri").(string)
+	data, err := c.vault.listItems("--url", uri)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var ids []interface{}
+	var items []interface{}
+	for _, item := range *data {
+		itemAsMap, ok := item.(map[string]interface{})
+		if !ok {
+			return diag.FromErr(fmt.Errorf("unexpected item format:\n%v", item))
+		}
+		if itemType, ok := itemAsMap["type"].(float64); !ok || int(itemType) != itemTypeLogin {
+			continue
+		}
+		nameUriMatchTypes(itemAsMap)
+		ids = append(ids, itemAsMap["id"])
+		items = append(items, itemAsMap)
+	}
+	enclosure := &map[string]interface{}{
+		"real": items,
+	}
+	err = encloseMaps(enclosure, &map[string]interface{}{
+		"real": []map[string]interface{}{itemMapsToEnclose},
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("items", (*enclosure)["real"]); err != nil {
+		return diag.FromErr(fmt.Errorf("%s\n\n%v", err.Error(), items))
+	}
+	d.SetId(uri)
+
+	return diags
+}
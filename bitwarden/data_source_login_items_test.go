@@ -0,0 +1,84 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testVault is a vault over fixed items; shaped like bw gives them, and fresh copies every time like bw does.
+type testVault struct {
+	items []interface{}
+}
+
+func (v *testVault) copyItems() []interface{} {
+	var items []interface{}
+	encoded, _ := json.Marshal(v.items)
+	json.Unmarshal(encoded, &items)
+	return items
+}
+
+func (v *testVault) getItem(id string) (*map[string]interface{}, error) {
+	for _, item := range v.copyItems() {
+		if itemAsMap := item.(map[string]interface{}); itemAsMap["id"] == id {
+			return &itemAsMap, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (v *testVault) listItems(filters ...string) (*[]interface{}, error) {
+	items := v.copyItems()
+	return &items, nil
+}
+
+func (v *testVault) getFolder(id string) (*map[string]interface{}, error) {
+	return nil, errNotFound
+}
+
+func (v *testVault) listFolders(filters ...string) (*[]interface{}, error) {
+	return &[]interface{}{}, nil
+}
+
+func TestDataSourceLoginItemsUriMatch(t *testing.T) {
+	c := &Client{vault: &testVault{items: []interface{}{
+		map[string]interface{}{
+			"id":   "l1",
+			"type": float64(itemTypeLogin),
+			"name": "login",
+			"login": map[string]interface{}{
+				"username": "alice",
+				"uris": []interface{}{
+					map[string]interface{}{"uri": "https://example.com", "match": nil},
+					map[string]interface{}{"uri": "https://example.com/a", "match": float64(0)},
+					map[string]interface{}{"uri": "https://example.com/b", "match": float64(3)},
+				},
+			},
+		},
+		map[string]interface{}{
+			"id":         "n1",
+			"type":       float64(2),
+			"name":       "note",
+			"secureNote": map[string]interface{}{"type": float64(0)},
+		},
+	}}}
+	d := schema.TestResourceDataRaw(t, dataSourceLoginItems().Schema, map[string]interface{}{"uri": "https://example.com"})
+	if diags := dataSourceLoginItemsRead(context.Background(), d, c); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if ids := d.Get("ids").([]interface{}); len(ids) != 1 || ids[0] != "l1" {
+		t.Errorf("expected only the login item, got %v", ids)
+	}
+	for key, expected := range map[string]string{
+		"items.0.login.0.uris.0.match": "",
+		"items.0.login.0.uris.1.match": "domain",
+		"items.0.login.0.uris.2.match": "exact",
+	} {
+		if match := d.Get(key).(string); match != expected {
+			t.Errorf("%s: expected %q, got %q", key, expected, match)
+		}
+	}
+}
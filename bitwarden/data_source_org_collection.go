@@ -0,0 +1,70 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrgCollection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrgCollectionRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"external_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceOrgCollectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	organizationId := d.Get("organization_id").(string)
+	name := d.Get("name").(string)
+	collections, err := c.bwListOrgCollections(organizationId, "--search", name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var matches []map[string]interface{}
+	for _, collection := range *collections {
+		collectionAsMap, ok := collection.(map[string]interface{})
+		if !ok {
+			return diag.FromErr(fmt.Errorf("unexpected org-collection format:\n%v", collection))
+		}
+		if collectionAsMap["name"] != name {
+			continue
+		}
+		matches = append(matches, collectionAsMap)
+	}
+
+	switch {
+	case len(matches) == 0:
+		return diag.FromErr(fmt.Errorf("no collection named %q in organization %s", name, organizationId))
+	case len(matches) > 1:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = fmt.Sprintf("%v", match["id"])
+		}
+		return diag.FromErr(fmt.Errorf("%d collections are named %q in organization %s:\n  - %s", len(matches), name, organizationId, strings.Join(ids, "\n  - ")))
+	}
+	if err := d.Set("external_id", matches[0]["external_id"]); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", matches[0]["id"]))
+
+	return diags
+}
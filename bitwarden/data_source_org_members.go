@@ -0,0 +1,70 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrgMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrgMembersRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": { // NOTE: 0 invited, 1 accepted, 2 confirmed, -1 revoked.
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": { // NOTE: 0 owner, 1 admin, 2 user, 3 manager, 4 custom.
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"two_factor_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrgMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	organizationId := d.Get("organization_id").(string)
+	members, err := c.bwListOrgMembers(organizationId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", *members); err != nil {
+		return diag.FromErr(fmt.Errorf("%s\n\n%v", err.Error(), members))
+	}
+	d.SetId(organizationId)
+
+	return diags
+}
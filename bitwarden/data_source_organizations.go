@@ -0,0 +1,61 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrganizations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrganizationsRead,
+		Schema: map[string]*schema.Schema{
+			"organizations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": { // NOTE: 0 invited, 1 accepted, 2 confirmed.
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": { // NOTE: 0 owner, 1 admin, 2 user, 3 manager, 4 custom.
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	organizations, err := c.bwListOrganizations()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("organizations", *organizations); err != nil {
+		return diag.FromErr(fmt.Errorf("%s\n\n%v", err.Error(), organizations))
+	}
+	d.SetId(c.userId) // NOTE: the organizations the logged in user belongs to.
+
+	return diags
+}
@@ -0,0 +1,80 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTotp() *schema.Resource {
+	// NOTE: the code is computed locally, like 'bw get totp' does, so it comes with the seconds it's still valid for.
+	return &schema.Resource{
+		ReadContext: dataSourceTotpRead,
+		Schema: map[string]*schema.Schema{
+			"item_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"item_id", "totp"},
+			},
+			"totp": { // NOTE: base32 secret, otpauth:// uri or steam:// secret; as stored in login items.
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"item_id", "totp"},
+			},
+			"code": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"seconds_remaining": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"period": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTotpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	key := d.Get("totp").(string)
+	if itemId, ok := d.GetOk("item_id"); ok {
+		item, err := c.vault.getItem(itemId.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		login, _ := (*item)["login"].(map[string]interface{})
+		key, _ = login["totp"].(string)
+		if key == "" {
+			return diag.FromErr(fmt.Errorf("item %s has no totp", itemId))
+		}
+	}
+	totp, err := parseTotpKey(key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now()
+	for key, value := range map[string]interface{}{
+		"code":              totp.code(now),
+		"seconds_remaining": totp.secondsRemaining(now),
+		"period":            totp.period,
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("cannot set %s: %s", key, err))
+		}
+	}
+	d.SetId(strconv.FormatInt(now.Unix()/int64(totp.period), 10)) // NOTE: the time step the code is valid for.
+
+	return diags
+}
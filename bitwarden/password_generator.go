@@ -0,0 +1,116 @@
+package bitwarden
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// passwordGeneratorSchema holds the options of 'bw generate'; with its defaults.
+func passwordGeneratorSchema(forceNew bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"length": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      14,
+			ValidateFunc: validation.IntBetween(5, 128),
+		},
+		"uppercase": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  true,
+		},
+		"lowercase": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  true,
+		},
+		"number": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  true,
+		},
+		"special": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  false,
+		},
+		"passphrase": { // NOTE: when set, only words, separator, capitalize and include_number apply.
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  false,
+		},
+		"words": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      3,
+			ValidateFunc: validation.IntBetween(3, 20),
+		},
+		"separator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     forceNew,
+			Default:      "-",
+			ValidateFunc: validation.StringLenBetween(0, 1),
+		},
+		"capitalize": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  false,
+		},
+		"include_number": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: forceNew,
+			Default:  false,
+		},
+	}
+}
+
+// passwordGeneratorArgs turns the options of passwordGeneratorSchema into 'bw generate' args.
+func passwordGeneratorArgs(options map[string]interface{}) []string {
+	if options["passphrase"].(bool) {
+		args := []string{
+			"--passphrase",
+			"--words", strconv.Itoa(options["words"].(int)),
+			"--separator", options["separator"].(string),
+		}
+		if options["capitalize"].(bool) {
+			args = append(args, "--capitalize")
+		}
+		if options["include_number"].(bool) {
+			args = append(args, "--includeNumber")
+		}
+		return args
+	}
+	args := []string{"--length", strconv.Itoa(options["length"].(int))}
+	for _, key := range []string{"uppercase", "lowercase", "number", "special"} {
+		if options[key].(bool) {
+			args = append(args, "--"+key)
+		}
+	}
+	return args
+}
+
+// passwordGeneratorOptions returns the options set in a passwordGeneratorSchema block; or the defaults when it's absent.
+func passwordGeneratorOptions(block []interface{}) map[string]interface{} {
+	if len(block) == 1 {
+		if options, ok := block[0].(map[string]interface{}); ok {
+			return options
+		}
+	}
+	options := map[string]interface{}{}
+	for key, value := range passwordGeneratorSchema(false) {
+		options[key] = value.Default
+	}
+	return options
+}
@@ -2,14 +2,19 @@ package bitwarden
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider -
 func Provider() *schema.Provider {
 	return &schema.Provider{
-		Schema: map[string]*schema.Schema{ // TODO allow specification of 'bw config server' etc. Ensure env vars don't collide. maybe decline to accept sensitive ones this way?
+		Schema: map[string]*schema.Schema{ // TODO maybe decline to accept sensitive ones this way?
 			"session_key": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true, // TODO
@@ -41,46 +46,214 @@ func Provider() *schema.Provider {
 				Sensitive:    true,
 				RequiredWith: []string{"client_id"},
 			},
-			"two_step_method": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
+			"two_step_method": &schema.Schema{ // NOTE: 0 authenticator app, 1 email, 3 yubikey.
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntInSlice([]int{0, 1, 3}),
 			},
 			"two_step_code": &schema.Schema{
-				Type:      schema.TypeString, // TODO always true?
-				Optional:  true,
-				Sensitive: true,
+				Type:          schema.TypeString, // TODO always true?
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"two_step_totp_secret"},
+			},
+			"two_step_totp_secret": &schema.Schema{ // NOTE: the authenticator app secret; to compute two_step_code at login time.
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"two_step_code"},
 			},
 			"server": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "https://bitwarden.com",
+				Default:  defaultServer,
+			},
+			"server_urls": &schema.Schema{ // NOTE: for split deployments; when the services aren't all reachable under server.
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"identity": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"web_vault": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"icons": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"notifications": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"events": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"key_connector": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"data_dir": &schema.Schema{ // NOTE: defaults to a temporary dir, so every run logs in again; or with just session_key, the host's bw data dir.
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"backend": &schema.Schema{ // NOTE: api reads items and folders from the server directly, and decrypts them in the provider; writes still use bw.
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      backendCli,
+				ValidateFunc: validation.StringInSlice([]string{backendCli, backendApi}, false),
+			},
+			"cli_path": &schema.Schema{ // NOTE: defaults to bw from PATH.
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"cli_download"},
+			},
+			"cli_download": &schema.Schema{ // NOTE: fetches a pinned bw release instead of using the host's; for runners without bw.
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"cli_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  defaultCliDownloadVersion,
+						},
+						"mirror_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  defaultCliDownloadMirror,
+						},
+						"sha256": { // NOTE: of the release zip; needed for versions without an embedded checksum.
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9a-fA-F]{64}$`), "must be a hex SHA-256 checksum"),
+						},
+						"cache_dir": { // NOTE: defaults to a dir under TF_PLUGIN_CACHE_DIR, or the user's cache dir.
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"ca_cert_file": &schema.Schema{ // NOTE: PEM file of extra CA certs to trust; e.g. for a self-hosted server behind an internal CA.
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_verify": &schema.Schema{ // NOTE: disables TLS certificate checks altogether; only for test labs.
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{},
+		ResourcesMap: map[string]*schema.Resource{
+			"bitwarden_folder":             resourceFolder(),
+			"bitwarden_generated_password": resourceGeneratedPassword(),
+			"bitwarden_item_attachment":    resourceItemAttachment(),
+			"bitwarden_item_card":          resourceItemCard(),
+			"bitwarden_item_identity":      resourceItemIdentity(),
+			"bitwarden_item_login":         resourceItemLogin(),
+			"bitwarden_item_secure_note":   resourceItemSecureNote(),
+			"bitwarden_org_collection":     resourceOrgCollection(),
+			"bitwarden_send":               resourceSend(),
+		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"bitwarden_item": dataSourceItem(),
+			"bitwarden_folder":          dataSourceFolder(),
+			"bitwarden_item":            dataSourceItem(),
+			"bitwarden_item_attachment": dataSourceItemAttachment(),
+			"bitwarden_items":           dataSourceItems(),
+			"bitwarden_login_items":     dataSourceLoginItems(),
+			"bitwarden_org_collection":  dataSourceOrgCollection(),
+			"bitwarden_org_members":     dataSourceOrgMembers(),
+			"bitwarden_organizations":   dataSourceOrganizations(),
+			"bitwarden_totp":            dataSourceTotp(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	sessionKey := d.Get("session_key").(string)
-	masterPassword := d.Get("master_password").(string)
-	email := d.Get("email").(string)
-	userId := d.Get("user_id").(string)
-	clientId := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
-	//twoStepMethod := d.Get("two_step_method").(int) // TODO
-	//twoStepCode := d.Get("two_step_code").(string) // TODO
-	server := d.Get("server").(string)
-
 	var diags diag.Diagnostics
 
-	c, err := NewClient(email, masterPassword, server, clientId, clientSecret, userId, sessionKey)
+	serverUrls := map[string]string{}
+	if block, ok := d.Get("server_urls").([]interface{}); ok && len(block) == 1 && block[0] != nil {
+		for key, url := range block[0].(map[string]interface{}) {
+			if url.(string) != "" {
+				serverUrls[strings.ReplaceAll(key, "_", "-")] = url.(string)
+			}
+		}
+	}
+
+	var cliDownload *CLIDownloadConfig
+	if block, ok := d.Get("cli_download").([]interface{}); ok && len(block) == 1 {
+		cliDownload = &CLIDownloadConfig{
+			Version:   defaultCliDownloadVersion,
+			MirrorUrl: defaultCliDownloadMirror,
+		}
+		if settings, ok := block[0].(map[string]interface{}); ok {
+			cliDownload.Version = settings["version"].(string)
+			cliDownload.MirrorUrl = settings["mirror_url"].(string)
+			cliDownload.Sha256 = settings["sha256"].(string)
+			cliDownload.CacheDir = settings["cache_dir"].(string)
+		}
+	}
+
+	c, err := NewClient(&ClientConfig{
+		SessionKey:         d.Get("session_key").(string),
+		MasterPassword:     d.Get("master_password").(string),
+		Email:              d.Get("email").(string),
+		UserId:             d.Get("user_id").(string),
+		ClientId:           d.Get("client_id").(string),
+		ClientSecret:       d.Get("client_secret").(string),
+		TwoStepMethod:      d.Get("two_step_method").(int),
+		TwoStepCode:        d.Get("two_step_code").(string),
+		TwoStepTotpSecret:  d.Get("two_step_totp_secret").(string),
+		Server:             d.Get("server").(string),
+		ServerUrls:         serverUrls,
+		DataDir:            d.Get("data_dir").(string),
+		CLIPath:            d.Get("cli_path").(string),
+		CLIDownload:        cliDownload,
+		Backend:            d.Get("backend").(string),
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	})
 	if err != nil {
 		return nil, append(diags, diag.FromErr(err)...)
 	}
+	if !c.cliVersion.lessThan(maximumCliVersion) { // NOTE: with backend api, bw isn't set up yet; so there's no version to check.
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Untested Bitwarden CLI version",
+			Detail:   fmt.Sprintf("bw %s at %s is newer than the versions this provider is known to work with (before %s); its output may not be understood.", c.cliVersion, c.BitwardenCLIBinary, maximumCliVersion),
+		})
+	}
+	if c.insecureSkipVerify {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   "insecure_skip_verify is set; the connection to the Bitwarden server can be intercepted. Only use it for test labs.",
+		})
+	}
 	return c, diags
 }
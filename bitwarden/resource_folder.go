@@ -0,0 +1,91 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFolder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFolderCreate,
+		ReadContext:   resourceFolderRead,
+		UpdateContext: resourceFolderUpdate,
+		DeleteContext: resourceFolderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	folder, err := c.bwCreateFolder(&map[string]interface{}{
+		"name": d.Get("name").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := (*folder)["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("unexpected create folder output:\n%v", folder))
+	}
+	d.SetId(id)
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	folder, err := c.vault.getFolder(d.Id())
+	if errors.Is(err, errNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", (*folder)["name"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	_, err := c.bwEditFolder(d.Id(), &map[string]interface{}{
+		"name": d.Get("name").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	err := c.bwDeleteFolder(d.Id())
+	if err != nil && !errors.Is(err, errNotFound) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
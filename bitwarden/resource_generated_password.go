@@ -0,0 +1,68 @@
+package bitwarden
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGeneratedPassword() *schema.Resource {
+	// NOTE: generated once and kept in state; only regenerated when keepers or the options change.
+	s := passwordGeneratorSchema(true)
+	s["keepers"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	s["result"] = &schema.Schema{
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	}
+	return &schema.Resource{
+		CreateContext: resourceGeneratedPasswordCreate,
+		ReadContext:   resourceGeneratedPasswordRead,
+		DeleteContext: resourceGeneratedPasswordDelete,
+		Schema:        s,
+	}
+}
+
+func resourceGeneratedPasswordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	options := map[string]interface{}{}
+	for key := range passwordGeneratorSchema(true) {
+		options[key] = d.Get(key)
+	}
+	password, err := c.bwGenerate(passwordGeneratorArgs(options)...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("result", password); err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return resourceGeneratedPasswordRead(ctx, d, m)
+}
+
+func resourceGeneratedPasswordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// NOTE: nothing to read; the password only exists in state.
+	return diags
+}
+
+func resourceGeneratedPasswordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
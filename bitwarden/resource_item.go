@@ -0,0 +1,316 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	itemTypeLogin      = 1
+	itemTypeSecureNote = 2
+	itemTypeCard       = 3
+	itemTypeIdentity   = 4
+)
+
+// itemKind describes an item resource. The attributes of its type specific sub-object (e.g. login) are kept at the top level.
+type itemKind struct {
+	itemType int
+	// key of the sub-object in the item, as converted by itemKeyConversion.
+	subObject string
+	// top level attributes that live in the sub-object.
+	schema map[string]*schema.Schema
+	// expand and flatten are optional; they default to copying the schema keys as is.
+	expand  func(d *schema.ResourceData, subObject map[string]interface{})
+	flatten func(d *schema.ResourceData, subObject map[string]interface{}) error
+	// prepare is optional; it runs before the item is expanded on create and update.
+	prepare       func(c *Client, d *schema.ResourceData) error
+	customizeDiff schema.CustomizeDiffFunc
+}
+
+func resourceItem(kind *itemKind) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"notes": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"favorite": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"folder_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"organization_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"collection_ids": {
+			Type:         schema.TypeSet,
+			Optional:     true,
+			RequiredWith: []string{"organization_id"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"fields": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"type": { // NOTE: 0 text, 1 hidden, 2 boolean. linked fields aren't supported.
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntBetween(0, 2),
+					},
+					"value": {
+						Type:      schema.TypeString,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		"revision_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for key, value := range kind.schema {
+		s[key] = value
+	}
+	return &schema.Resource{
+		CreateContext: kind.create,
+		ReadContext:   kind.read,
+		UpdateContext: kind.update,
+		DeleteContext: kind.delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: kind.customizeDiffs(),
+		Schema:        s,
+	}
+}
+
+func (kind *itemKind) customizeDiffs() schema.CustomizeDiffFunc {
+	customizeDiffs := []schema.CustomizeDiffFunc{
+		customdiff.ForceNewIfChange("organization_id", func(ctx context.Context, old, new, meta interface{}) bool {
+			return old.(string) != "" // NOTE: items can only be moved from the personal vault into an organization; not back or between organizations.
+		}),
+		customizeDiffCollectionIds,
+	}
+	if kind.customizeDiff != nil {
+		customizeDiffs = append(customizeDiffs, kind.customizeDiff)
+	}
+	return customdiff.All(customizeDiffs...)
+}
+
+// customizeDiffCollectionIds requires collection_ids with organization_id; bw create and move fail without them, but only at apply.
+func customizeDiffCollectionIds(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("organization_id") || !d.NewValueKnown("collection_ids") {
+		return nil
+	}
+	if d.Get("organization_id").(string) != "" && d.Get("collection_ids").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("collection_ids needs at least one collection when organization_id is set")
+	}
+	return nil
+}
+
+func (kind *itemKind) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if kind.prepare != nil {
+		err := kind.prepare(c, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	item, err := c.bwGetTemplate("item", itemKeyConversion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	kind.expandItem(d, item)
+	created, err := c.bwCreateItem(item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := (*created)["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("unexpected create item output:\n%v", created))
+	}
+	d.SetId(id)
+
+	return kind.read(ctx, d, m)
+}
+
+func (kind *itemKind) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	item, err := c.vault.getItem(d.Id())
+	if errors.Is(err, errNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if (*item)["deletedDate"] != nil { // NOTE: items in the trash are still returned.
+		d.SetId("")
+		return diags
+	}
+	if itemType, ok := (*item)["type"].(float64); !ok || int(itemType) != kind.itemType {
+		return diag.FromErr(fmt.Errorf("item %s has type %v, expected %d", d.Id(), (*item)["type"], kind.itemType))
+	}
+	subObject, _ := (*item)[kind.subObject].(map[string]interface{})
+	err = setItemData(d, item, resourceItem(kind).Schema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = kind.flattenSubObject(d, subObject)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func (kind *itemKind) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if kind.prepare != nil {
+		err := kind.prepare(c, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// NOTE: organization and collection changes are done in place, so the id, attachments and password history are kept.
+	organizationId := d.Get("organization_id").(string)
+	collectionIds := d.Get("collection_ids").(*schema.Set).List()
+	if d.HasChange("organization_id") {
+		_, err := c.bwMoveItem(d.Id(), organizationId, collectionIds)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange("collection_ids") {
+		_, err := c.bwEditItemCollections(d.Id(), organizationId, collectionIds)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChangesExcept("organization_id", "collection_ids") {
+		item, err := c.bwGetItem(d.Id()) // NOTE: edit replaces the whole item; start from the current one so unmanaged keys survive.
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		kind.expandItem(d, item)
+		_, err = c.bwEditItem(d.Id(), item)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return kind.read(ctx, d, m)
+}
+
+func (kind *itemKind) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	err := c.bwDeleteItem(d.Id())
+	if err != nil && !errors.Is(err, errNotFound) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
+
+func (kind *itemKind) expandItem(d *schema.ResourceData, item *map[string]interface{}) {
+	(*item)["type"] = kind.itemType
+	(*item)["name"] = d.Get("name").(string)
+	(*item)["notes"] = nullIfEmpty(d.Get("notes").(string))
+	(*item)["favorite"] = d.Get("favorite").(bool)
+	(*item)["folder_id"] = nullIfEmpty(d.Get("folder_id").(string))
+	(*item)["organization_id"] = nullIfEmpty(d.Get("organization_id").(string))
+	(*item)["collection_ids"] = d.Get("collection_ids").(*schema.Set).List()
+
+	fields := []interface{}{}
+	for _, field := range d.Get("fields").([]interface{}) {
+		fieldAsMap := field.(map[string]interface{})
+		fields = append(fields, map[string]interface{}{
+			"name":  fieldAsMap["name"],
+			"type":  fieldAsMap["type"],
+			"value": nullIfEmpty(fieldAsMap["value"].(string)),
+		})
+	}
+	(*item)["fields"] = fields
+
+	subObject, ok := (*item)[kind.subObject].(map[string]interface{})
+	if !ok {
+		subObject = map[string]interface{}{}
+	}
+	if kind.expand != nil {
+		kind.expand(d, subObject)
+	} else {
+		for key := range kind.schema {
+			if value, ok := d.Get(key).(string); ok {
+				subObject[key] = nullIfEmpty(value)
+			} else {
+				subObject[key] = d.Get(key)
+			}
+		}
+	}
+	(*item)[kind.subObject] = subObject
+}
+
+func (kind *itemKind) flattenSubObject(d *schema.ResourceData, subObject map[string]interface{}) error {
+	if kind.flatten != nil {
+		return kind.flatten(d, subObject)
+	}
+	for key := range kind.schema {
+		if err := d.Set(key, subObject[key]); err != nil {
+			return fmt.Errorf("cannot set %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// subObjectSchema turns the computed schema of an item sub-object (see itemSchema) into optional top level attributes.
+func subObjectSchema(subObject string) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for key, value := range itemSchema()[subObject].Elem.(*schema.Resource).Schema {
+		s[key] = &schema.Schema{
+			Type:      value.Type,
+			Optional:  true,
+			Sensitive: value.Sensitive,
+		}
+	}
+	return s
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,147 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceItemAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceItemAttachmentCreate,
+		ReadContext:   resourceItemAttachmentRead,
+		DeleteContext: resourceItemAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceItemAttachmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"item_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file": { // NOTE: path of the file to upload. Only changes to the path are noticed, not to the content.
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceItemAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	itemId := d.Get("item_id").(string)
+	item, err := c.vault.getItem(itemId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	existing := map[interface{}]bool{}
+	for _, attachment := range itemAttachments(item) {
+		existing[attachment["id"]] = true
+	}
+	item, err = c.bwCreateAttachment(itemId, d.Get("file").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, attachment := range itemAttachments(item) { // NOTE: bw returns the item; the new attachment is the one that wasn't there before.
+		if id, ok := attachment["id"].(string); ok && !existing[id] {
+			d.SetId(id)
+			return resourceItemAttachmentRead(ctx, d, m)
+		}
+	}
+	return diag.FromErr(fmt.Errorf("cannot find the created attachment in item %s:\n%v", itemId, item))
+}
+
+func resourceItemAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	item, err := c.vault.getItem(d.Get("item_id").(string))
+	if errors.Is(err, errNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attachment, ok := findAttachment(item, d.Id())
+	if !ok {
+		d.SetId("")
+		return diags
+	}
+	for _, key := range []string{"file_name", "size", "size_name", "url"} {
+		if err := d.Set(key, attachment[key]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceItemAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	err := c.bwDeleteAttachment(d.Get("item_id").(string), d.Id())
+	if err != nil && !errors.Is(err, errNotFound) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
+
+func resourceItemAttachmentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// NOTE: import ids are <item_id>/<id>. file stays unknown, so the next plan will replace the attachment unless it's ignored.
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import id %q, expected <item_id>/<id>", d.Id())
+	}
+	if err := d.Set("item_id", parts[0]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func itemAttachments(item *map[string]interface{}) []map[string]interface{} {
+	var attachments []map[string]interface{}
+	list, _ := (*item)["attachments"].([]interface{})
+	for _, attachment := range list {
+		if attachmentAsMap, ok := attachment.(map[string]interface{}); ok {
+			attachments = append(attachments, attachmentAsMap)
+		}
+	}
+	return attachments
+}
+
+func findAttachment(item *map[string]interface{}, id string) (map[string]interface{}, bool) {
+	for _, attachment := range itemAttachments(item) {
+		if attachment["id"] == id {
+			return attachment, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,13 @@
+package bitwarden
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceItemCard() *schema.Resource {
+	return resourceItem(&itemKind{
+		itemType:  itemTypeCard,
+		subObject: "card",
+		schema:    subObjectSchema("card"),
+	})
+}
@@ -0,0 +1,13 @@
+package bitwarden
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceItemIdentity() *schema.Resource {
+	return resourceItem(&itemKind{
+		itemType:  itemTypeIdentity,
+		subObject: "identity",
+		schema:    subObjectSchema("identity"),
+	})
+}
@@ -0,0 +1,244 @@
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// uriMatchTypes are the names of the uri match types; indexed by the values bw uses. Unset means the default (domain, unless changed in the client settings).
+var uriMatchTypes = []string{"domain", "host", "starts_with", "exact", "regular_expression", "never"}
+
+func resourceItemLogin() *schema.Resource {
+	return resourceItem(&itemKind{
+		itemType:  itemTypeLogin,
+		subObject: "login",
+		schema: map[string]*schema.Schema{
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"generated_password": { // NOTE: the password when rotated; see rotation_days and rotate_after. password is empty then.
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"password_generated_at": { // NOTE: when generated_password was last generated; bw's password_revision_date stays empty until the first change.
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"totp": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uri": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"match": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(uriMatchTypes, false),
+						},
+					},
+				},
+			},
+			"password_revision_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rotation_days": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"password"},
+			},
+			"rotate_after": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"password"},
+			},
+			"password_generator": { // NOTE: options for the passwords generated by rotation.
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: passwordGeneratorSchema(false),
+				},
+			},
+		},
+		expand:        expandLogin,
+		flatten:       flattenLogin,
+		prepare:       prepareLogin,
+		customizeDiff: customizeDiffLogin,
+	})
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+func passwordRotationConfigured(d resourceGetter) bool {
+	_, rotationDays := d.GetOk("rotation_days")
+	_, rotateAfter := d.GetOk("rotate_after")
+	return rotationDays || rotateAfter
+}
+
+// passwordChanged gives when the password last changed; the later of bw's password_revision_date and password_generated_at.
+func passwordChanged(d resourceGetter) (time.Time, bool) {
+	var changed time.Time
+	for _, key := range []string{"password_revision_date", "password_generated_at"} {
+		if parsed, err := time.Parse(time.RFC3339, d.Get(key).(string)); err == nil && parsed.After(changed) {
+			changed = parsed
+		}
+	}
+	if !changed.IsZero() {
+		return changed, true
+	}
+	// NOTE: only for imported items, whose password wasn't generated here and never changed; any edit moves revision_date.
+	parsed, err := time.Parse(time.RFC3339, d.Get("revision_date").(string))
+	return parsed, err == nil
+}
+
+func passwordRotationDue(d resourceGetter, now time.Time) bool {
+	changed, ok := passwordChanged(d)
+	if !ok {
+		return false
+	}
+	if rotationDays, ok := d.GetOk("rotation_days"); ok && now.After(changed.AddDate(0, 0, rotationDays.(int))) {
+		return true
+	}
+	if rotateAfter, ok := d.GetOk("rotate_after"); ok {
+		rotateAfterTime, err := time.Parse(time.RFC3339, rotateAfter.(string))
+		if err == nil && now.After(rotateAfterTime) && changed.Before(rotateAfterTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func customizeDiffLogin(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if !passwordRotationConfigured(d) {
+		if d.Get("generated_password").(string) != "" { // NOTE: rotation was turned off; password is managed again.
+			// NOTE: an unset password would clear the one in the vault; so it has to be given, e.g. the last generated_password.
+			if d.NewValueKnown("password") && d.Get("password").(string) == "" {
+				return fmt.Errorf("password rotation was turned off for %s; set password to keep managing it, the current one is in generated_password", d.Id())
+			}
+			return d.SetNew("generated_password", "")
+		}
+		return nil
+	}
+	if d.Get("generated_password").(string) == "" || passwordRotationDue(d, time.Now()) {
+		for _, key := range []string{"generated_password", "password_generated_at"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func prepareLogin(c *Client, d *schema.ResourceData) error {
+	// NOTE: with rotation, the password is generated here; on create, and once customizeDiffLogin found it stale or missing.
+	if !passwordRotationConfigured(d) || d.Get("generated_password").(string) != "" {
+		return nil
+	}
+	options := passwordGeneratorOptions(d.Get("password_generator").([]interface{}))
+	password, err := c.bwGenerate(passwordGeneratorArgs(options)...)
+	if err != nil {
+		return err
+	}
+	err = d.Set("generated_password", password)
+	if err != nil {
+		return err
+	}
+	return d.Set("password_generated_at", time.Now().UTC().Format(time.RFC3339))
+}
+
+func expandLogin(d *schema.ResourceData, login map[string]interface{}) {
+	login["username"] = nullIfEmpty(d.Get("username").(string))
+	if passwordRotationConfigured(d) {
+		login["password"] = nullIfEmpty(d.Get("generated_password").(string))
+	} else {
+		login["password"] = nullIfEmpty(d.Get("password").(string))
+	}
+	login["totp"] = nullIfEmpty(d.Get("totp").(string))
+
+	uris := []interface{}{}
+	for _, uri := range d.Get("uris").([]interface{}) {
+		uriAsMap := uri.(map[string]interface{})
+		var match interface{}
+		for i, matchType := range uriMatchTypes {
+			if matchType == uriAsMap["match"] {
+				match = i
+			}
+		}
+		uris = append(uris, map[string]interface{}{
+			"uri":   uriAsMap["uri"],
+			"match": match,
+		})
+	}
+	login["uris"] = uris
+}
+
+// uriMatchName gives the name of a uri match type as bw gives it; "" for the default (null).
+func uriMatchName(match interface{}) string {
+	if matchIndex, ok := match.(float64); ok && matchIndex >= 0 && int(matchIndex) < len(uriMatchTypes) {
+		return uriMatchTypes[int(matchIndex)]
+	}
+	return ""
+}
+
+func flattenLogin(d *schema.ResourceData, login map[string]interface{}) error {
+	var uris []interface{}
+	if loginUris, ok := login["uris"].([]interface{}); ok {
+		for _, uri := range loginUris {
+			uriAsMap, ok := uri.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected uri format:\n%v", uri)
+			}
+			uris = append(uris, map[string]interface{}{
+				"uri":   uriAsMap["uri"],
+				"match": uriMatchName(uriAsMap["match"]),
+			})
+		}
+	}
+
+	password, generatedPassword := login["password"], interface{}("")
+	if passwordRotationConfigured(d) {
+		password, generatedPassword = "", login["password"]
+	}
+	for key, value := range map[string]interface{}{
+		"username":               login["username"],
+		"password":               password,
+		"generated_password":     generatedPassword,
+		"totp":                   login["totp"],
+		"uris":                   uris,
+		"password_revision_date": login["password_revision_date"],
+	} {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("cannot set %s: %s", key, err)
+		}
+	}
+	return nil
+}
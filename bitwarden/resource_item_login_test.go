@@ -0,0 +1,127 @@
+package bitwarden
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testResourceGetter is a resourceGetter over fixed values; unset ones are the zero values, as in schema.ResourceData.
+type testResourceGetter map[string]interface{}
+
+func (g testResourceGetter) Get(key string) interface{} {
+	if value, ok := g[key]; ok {
+		return value
+	}
+	switch key {
+	case "rotation_days":
+		return 0
+	}
+	return ""
+}
+
+func (g testResourceGetter) GetOk(key string) (interface{}, bool) {
+	value := g.Get(key)
+	return value, value != 0 && value != ""
+}
+
+func TestPasswordRotationDue(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		values   testResourceGetter
+		expected bool
+	}{
+		{
+			name:     "disabled",
+			values:   testResourceGetter{"password_generated_at": "2020-01-01T00:00:00Z"},
+			expected: false,
+		},
+		{
+			name:     "rotation_days due",
+			values:   testResourceGetter{"rotation_days": 30, "password_generated_at": "2021-04-01T00:00:00Z"},
+			expected: true,
+		},
+		{
+			name:     "rotation_days not due",
+			values:   testResourceGetter{"rotation_days": 30, "password_generated_at": "2021-05-15T00:00:00Z"},
+			expected: false,
+		},
+		{
+			name:     "rotation_days from the later of the dates",
+			values:   testResourceGetter{"rotation_days": 30, "password_generated_at": "2021-04-01T00:00:00Z", "password_revision_date": "2021-05-15T00:00:00.000Z"},
+			expected: false,
+		},
+		{
+			name:     "rotation_days not reset by edits",
+			values:   testResourceGetter{"rotation_days": 30, "password_generated_at": "2021-04-01T00:00:00Z", "revision_date": "2021-05-31T00:00:00.000Z"},
+			expected: true,
+		},
+		{
+			name:     "rotation_days of an imported item",
+			values:   testResourceGetter{"rotation_days": 30, "revision_date": "2021-04-01T00:00:00.000Z"},
+			expected: true,
+		},
+		{
+			name:     "rotation_days without any date",
+			values:   testResourceGetter{"rotation_days": 30},
+			expected: false,
+		},
+		{
+			name:     "rotate_after due",
+			values:   testResourceGetter{"rotate_after": "2021-05-01T00:00:00Z", "password_generated_at": "2021-04-01T00:00:00Z"},
+			expected: true,
+		},
+		{
+			name:     "rotate_after already rotated",
+			values:   testResourceGetter{"rotate_after": "2021-05-01T00:00:00Z", "password_generated_at": "2021-05-02T00:00:00Z"},
+			expected: false,
+		},
+		{
+			name:     "rotate_after not yet",
+			values:   testResourceGetter{"rotate_after": "2021-07-01T00:00:00Z", "password_generated_at": "2021-04-01T00:00:00Z"},
+			expected: false,
+		},
+		{
+			name:     "either due",
+			values:   testResourceGetter{"rotation_days": 365, "rotate_after": "2021-05-01T00:00:00Z", "password_generated_at": "2021-04-01T00:00:00Z"},
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if due := passwordRotationDue(test.values, now); due != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, due)
+			}
+		})
+	}
+}
+
+func TestCustomizeDiffLoginRotationTurnedOff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "11111111-2222-3333-4444-555555555555",
+		Attributes: map[string]string{
+			"id":                    "11111111-2222-3333-4444-555555555555",
+			"name":                  "n",
+			"rotation_days":         "30",
+			"generated_password":    "generated",
+			"password_generated_at": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	_, err := resourceItemLogin().Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"name": "n"}), nil)
+	if err == nil || !strings.Contains(err.Error(), "set password") {
+		t.Errorf("expected turning rotation off without password to fail, got %v", err)
+	}
+
+	diff, err := resourceItemLogin().Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"name": "n", "password": "generated"}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attribute, ok := diff.Attributes["generated_password"]; !ok || attribute.New != "" {
+		t.Errorf("expected generated_password to be cleared, got %v", attribute)
+	}
+}
@@ -0,0 +1,17 @@
+package bitwarden
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceItemSecureNote() *schema.Resource {
+	// NOTE: the note body is the item's notes. Edits made in the UI show up as drift, as NewClient syncs before anything is read.
+	return resourceItem(&itemKind{
+		itemType:  itemTypeSecureNote,
+		subObject: "secure_note",
+		schema:    map[string]*schema.Schema{},
+		expand: func(d *schema.ResourceData, secureNote map[string]interface{}) {
+			secureNote["type"] = 0 // NOTE: generic is the only secure note type.
+		},
+	})
+}
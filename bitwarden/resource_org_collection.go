@@ -0,0 +1,125 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceOrgCollection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrgCollectionCreate,
+		ReadContext:   resourceOrgCollectionRead,
+		UpdateContext: resourceOrgCollectionUpdate,
+		DeleteContext: resourceOrgCollectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrgCollectionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"external_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceOrgCollectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	organizationId := d.Get("organization_id").(string)
+	collection, err := c.bwCreateOrgCollection(organizationId, &map[string]interface{}{
+		"organization_id": organizationId,
+		"name":            d.Get("name").(string),
+		"external_id":     nullIfEmpty(d.Get("external_id").(string)),
+		"groups":          []interface{}{},
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := (*collection)["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("unexpected create org-collection output:\n%v", collection))
+	}
+	d.SetId(id)
+
+	return resourceOrgCollectionRead(ctx, d, m)
+}
+
+func resourceOrgCollectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	collection, err := c.bwGetOrgCollection(d.Get("organization_id").(string), d.Id())
+	if errors.Is(err, errNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, key := range []string{"organization_id", "name", "external_id"} {
+		if err := d.Set(key, (*collection)[key]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceOrgCollectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	organizationId := d.Get("organization_id").(string)
+	collection, err := c.bwGetOrgCollection(organizationId, d.Id()) // NOTE: start from the current one so the group assignments survive.
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	(*collection)["name"] = d.Get("name").(string)
+	(*collection)["external_id"] = nullIfEmpty(d.Get("external_id").(string))
+	_, err = c.bwEditOrgCollection(organizationId, d.Id(), collection)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceOrgCollectionRead(ctx, d, m)
+}
+
+func resourceOrgCollectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	err := c.bwDeleteOrgCollection(d.Get("organization_id").(string), d.Id())
+	if err != nil && !errors.Is(err, errNotFound) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
+
+func resourceOrgCollectionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// NOTE: the organization is needed to get the collection; so import ids are <organization_id>/<id>.
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import id %q, expected <organization_id>/<id>", d.Id())
+	}
+	if err := d.Set("organization_id", parts[0]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
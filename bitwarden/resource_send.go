@@ -0,0 +1,263 @@
+package bitwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	sendTypeText = 0
+	sendTypeFile = 1
+)
+
+func resourceSend() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSendCreate,
+		ReadContext:   resourceSendRead,
+		UpdateContext: resourceSendUpdate,
+		DeleteContext: resourceSendDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"text": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"text", "file"},
+			},
+			"hidden": { // NOTE: only applies to text sends.
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"file": { // NOTE: path of the file to send. The content of a file send can't be changed.
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"text", "file"},
+			},
+			"file_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deletion_date": { // NOTE: defaults to 7 days after creation; at most 31 days.
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			"expiration_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			"max_access_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"password": { // NOTE: write only; bw only tells whether one is set.
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"hide_email": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"access_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"revision_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	template := "send.text"
+	if _, ok := d.GetOk("file"); ok {
+		template = "send.file"
+	}
+	send, err := c.bwGetTemplate(template, sendKeyConversion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	expandSend(d, send)
+	defaultSendDeletionDate(send, time.Now())
+	if file, ok := d.GetOk("file"); ok {
+		(*send)["file"] = map[string]interface{}{
+			"file_name": file.(string),
+		}
+	}
+	(*send)["password"] = nullIfEmpty(d.Get("password").(string))
+	created, err := c.bwCreateSend(send)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := (*created)["id"].(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("unexpected send create output:\n%v", created))
+	}
+	d.SetId(id)
+
+	return resourceSendRead(ctx, d, m)
+}
+
+func resourceSendRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	send, err := c.bwGetSend(d.Id())
+	if errors.Is(err, errNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{}
+	for _, key := range []string{"name", "notes", "deletion_date", "expiration_date", "max_access_count", "disabled", "hide_email", "access_id", "access_url", "access_count", "revision_date"} {
+		values[key] = (*send)[key]
+	}
+	if text, ok := (*send)["text"].(map[string]interface{}); ok {
+		values["text"] = text["text"]
+		values["hidden"] = text["hidden"]
+	}
+	if file, ok := (*send)["file"].(map[string]interface{}); ok {
+		values["file_name"] = file["file_name"]
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("cannot set %s: %s", key, err))
+		}
+	}
+
+	return diags
+}
+
+func resourceSendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	password := d.Get("password").(string)
+	if d.HasChange("password") && password == "" {
+		err := c.bwRemoveSendPassword(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	send, err := c.bwGetSend(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	expandSend(d, send)
+	(*send)["password"] = nil // NOTE: leaves the current password as is.
+	if d.HasChange("password") {
+		(*send)["password"] = nullIfEmpty(password)
+	}
+	_, err = c.bwEditSend(send)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSendRead(ctx, d, m)
+}
+
+func resourceSendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+	err := c.bwDeleteSend(d.Id())
+	if err != nil && !errors.Is(err, errNotFound) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
+
+func expandSend(d *schema.ResourceData, send *map[string]interface{}) {
+	(*send)["name"] = d.Get("name").(string)
+	(*send)["notes"] = nullIfEmpty(d.Get("notes").(string))
+	(*send)["disabled"] = d.Get("disabled").(bool)
+	(*send)["hide_email"] = d.Get("hide_email").(bool)
+	(*send)["expiration_date"] = nullIfEmpty(d.Get("expiration_date").(string))
+	if deletionDate, ok := d.GetOk("deletion_date"); ok {
+		(*send)["deletion_date"] = deletionDate.(string)
+	}
+	(*send)["max_access_count"] = nil
+	if maxAccessCount, ok := d.GetOk("max_access_count"); ok {
+		(*send)["max_access_count"] = maxAccessCount.(int)
+	}
+	if _, ok := d.GetOk("file"); ok {
+		(*send)["type"] = sendTypeFile
+	} else {
+		(*send)["type"] = sendTypeText
+		(*send)["text"] = map[string]interface{}{
+			"text":   d.Get("text").(string),
+			"hidden": d.Get("hidden").(bool),
+		}
+	}
+}
+
+// sendDefaultLifetime is how long a send lasts without deletion_date; as the clients default it.
+const sendDefaultLifetime = 7 * 24 * time.Hour
+
+// defaultSendDeletionDate sets the deletion date of a new send that has none; from neither the config nor bw's template.
+func defaultSendDeletionDate(send *map[string]interface{}, now time.Time) {
+	if deletionDate, ok := (*send)["deletion_date"].(string); ok && deletionDate != "" {
+		return
+	}
+	(*send)["deletion_date"] = now.UTC().Add(sendDefaultLifetime).Format(time.RFC3339)
+}
+
+func suppressEquivalentTimes(k, old, new string, d *schema.ResourceData) bool {
+	// NOTE: bw returns times with milliseconds; e.g. 2021-01-01T00:00:00.000Z.
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
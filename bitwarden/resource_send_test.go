@@ -0,0 +1,127 @@
+package bitwarden
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDefaultSendDeletionDate(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name     string
+		send     map[string]interface{}
+		expected string
+	}{
+		{"unset", map[string]interface{}{}, "2021-01-08T11:00:00Z"},
+		{"null", map[string]interface{}{"deletion_date": nil}, "2021-01-08T11:00:00Z"},
+		{"empty", map[string]interface{}{"deletion_date": ""}, "2021-01-08T11:00:00Z"},
+		{"from the template or config", map[string]interface{}{"deletion_date": "2021-01-02T00:00:00.000Z"}, "2021-01-02T00:00:00.000Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaultSendDeletionDate(&test.send, now)
+			if deletionDate := test.send["deletion_date"]; deletionDate != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, deletionDate)
+			}
+		})
+	}
+}
+
+// newTestSendClient gives a client with a fake bw; that answers get template with template, and keeps the send it's asked to create.
+func newTestSendClient(t *testing.T, template string) (*Client, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake bw is a shell script")
+	}
+	dir := t.TempDir()
+	created := filepath.Join(dir, "created")
+	script := fmt.Sprintf(`#!/bin/sh
+cat > /dev/null
+case "$1 $2" in
+"get template") echo '{"success":true,"data":{"object":"template","template":%s}}' ;;
+"send create") echo "$3" > %s; echo '{"success":true,"data":{"object":"send","id":"s1"}}' ;;
+"send get") echo '{"success":true,"data":{"object":"send","id":"s1","name":"n","deletionDate":"2021-01-08T00:00:00.000Z"}}' ;;
+*) exit 1 ;;
+esac
+`, template, created)
+	bin := filepath.Join(dir, "bw")
+	if err := ioutil.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{BitwardenCLIBinary: bin, DataDir: dir, cliVersion: cliVersion{1, 22, 1}, cliSetup: &sync.Once{}, mutex: &sync.Mutex{}, mutexAuth: &sync.Mutex{}}
+	c.cliSetup.Do(func() {})
+	return c, created
+}
+
+func TestResourceSendCreateDeletionDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		config   map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "from the template",
+			template: `{"name":"Send name","type":0,"text":{"text":"Text contained in the send.","hidden":false},"deletionDate":"2021-01-08T00:00:00.000Z"}`,
+			config:   map[string]interface{}{"name": "n", "text": "secret"},
+			expected: "2021-01-08T00:00:00.000Z",
+		},
+		{
+			name:     "from the config",
+			template: `{"name":"Send name","type":0,"text":{"text":"Text contained in the send.","hidden":false},"deletionDate":"2021-01-08T00:00:00.000Z"}`,
+			config:   map[string]interface{}{"name": "n", "text": "secret", "deletion_date": "2021-01-02T00:00:00Z"},
+			expected: "2021-01-02T00:00:00Z",
+		},
+		{
+			name:     "without one in the template",
+			template: `{"name":"Send name","type":0,"text":{"text":"Text contained in the send.","hidden":false}}`,
+			config:   map[string]interface{}{"name": "n", "text": "secret"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, created := newTestSendClient(t, test.template)
+			d := schema.TestResourceDataRaw(t, resourceSend().Schema, test.config)
+			if diags := resourceSendCreate(context.Background(), d, c); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			encoded, err := ioutil.ReadFile(created)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(string(encoded))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var send map[string]interface{}
+			if err := json.Unmarshal(decoded, &send); err != nil {
+				t.Fatal(err)
+			}
+			deletionDate, _ := send["deletionDate"].(string)
+			if test.expected == "" {
+				parsed, err := time.Parse(time.RFC3339, deletionDate)
+				if err != nil {
+					t.Fatalf("expected a deletion date, got %v", send["deletionDate"])
+				}
+				if lifetime := time.Until(parsed); lifetime < sendDefaultLifetime-time.Hour || lifetime > sendDefaultLifetime {
+					t.Errorf("expected a deletion date in about 7 days, got %s", deletionDate)
+				}
+			} else if deletionDate != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, send["deletionDate"])
+			}
+			if text, _ := send["text"].(map[string]interface{}); text["text"] != "secret" {
+				t.Errorf("expected the configured text, got %v", send["text"])
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package bitwarden
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	fieldTypeText    = 0
+	fieldTypeHidden  = 1
+	fieldTypeBoolean = 2
+	fieldTypeLinked  = 3
+)
+
+// itemSchema is the computed schema of a single item; shared by the item data sources.
+func itemSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"object": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"organization_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"folder_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"notes": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"favorite": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"fields": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"value": { // NOTE this is a string even when it's a bool field.
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"linked_id": { // NOTE: only set for linked fields; the id of the item attribute linked to.
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"secure_note": {
+			Type:     schema.TypeList,
+			Computed: true,
+			//MinItems: 1,
+			//MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"identity": {
+			Type:     schema.TypeList,
+			Computed: true,
+			//MinItems: 1,
+			//MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"title": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"first_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"middle_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"last_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"address1": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"address2": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"address3": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"city": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"state": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"postal_code": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"country": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"company": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"email": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"phone": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ssn": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"username": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"passport_number": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"license_number": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		"card": {
+			Type:     schema.TypeList,
+			Computed: true,
+			//MinItems: 1,
+			//MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cardholder_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"brand": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"number": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"exp_month": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"exp_year": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"code": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		"login": {
+			Type:     schema.TypeList,
+			Computed: true,
+			//MinItems: 1,
+			//MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uris": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"match": {
+									Type:     schema.TypeInt, // TODO sometimes null
+									Computed: true,
+								},
+								"uri": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+					"username": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"password": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"totp": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"password_revision_date": {
+						Type:     schema.TypeString, // TODO sometimes null
+						Computed: true,
+					},
+				},
+			},
+		},
+		"collection_ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString, // NOTE: ids of org collections; see bitwarden_org_collection.
+			},
+		},
+		"revision_date": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"attachments": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"file_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"size": { // NOTE: bytes, as a string.
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"size_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"url": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// itemMapsToEnclose lists the item sub-objects that have to be wrapped in a single element list before being set.
+var itemMapsToEnclose = map[string]interface{}{
+	"card":        "",
+	"identity":    "",
+	"login":       "",
+	"secure_note": "",
+}
+
+func setItemData(d *schema.ResourceData, item *map[string]interface{}, itemSchema map[string]*schema.Schema) error {
+	// NOTE: keys missing from the schema (attachments, passwordHistory etc.) are skipped; d.Set fails on them.
+	err := encloseMaps(item, &itemMapsToEnclose)
+	if err != nil {
+		return err
+	}
+	for key, value := range *item {
+		if key == "id" {
+			continue
+		}
+		if _, ok := itemSchema[key]; !ok {
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("cannot set %s: %s", key, err)
+		}
+	}
+	if id, ok := (*item)["id"].(string); ok {
+		d.SetId(id)
+	}
+	return nil
+}
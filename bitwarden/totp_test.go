@@ -0,0 +1,127 @@
+package bitwarden
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	// NOTE: the secrets of RFC 6238 appendix B; "12345678901234567890" repeated to the length of each hash, in base32.
+	rfc6238SecretSha1   = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	rfc6238SecretSha256 = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA===="
+	rfc6238SecretSha512 = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA="
+)
+
+func TestTotpCodeRfc6238(t *testing.T) {
+	keys := map[string]string{
+		"sha1":   "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&digits=8",
+		"sha256": "otpauth://totp/test?secret=" + rfc6238SecretSha256 + "&digits=8&algorithm=SHA256",
+		"sha512": "otpauth://totp/test?secret=" + rfc6238SecretSha512 + "&digits=8&algorithm=SHA512",
+	}
+	tests := []struct {
+		time  int64
+		codes map[string]string
+	}{
+		{59, map[string]string{"sha1": "94287082", "sha256": "46119246", "sha512": "90693936"}},
+		{1111111109, map[string]string{"sha1": "07081804", "sha256": "68084774", "sha512": "25091201"}},
+		{1111111111, map[string]string{"sha1": "14050471", "sha256": "67062674", "sha512": "99943326"}},
+		{1234567890, map[string]string{"sha1": "89005924", "sha256": "91819424", "sha512": "93441116"}},
+		{2000000000, map[string]string{"sha1": "69279037", "sha256": "90698825", "sha512": "38618901"}},
+		{20000000000, map[string]string{"sha1": "65353130", "sha256": "77737706", "sha512": "47863826"}},
+	}
+	for algorithm, key := range keys {
+		totp, err := parseTotpKey(key)
+		if err != nil {
+			t.Fatalf("%s: %s", algorithm, err)
+		}
+		for _, test := range tests {
+			if code := totp.code(time.Unix(test.time, 0)); code != test.codes[algorithm] {
+				t.Errorf("%s at %d: expected %s, got %s", algorithm, test.time, test.codes[algorithm], code)
+			}
+		}
+	}
+}
+
+func TestTotpCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		time     int64
+		expected string
+	}{
+		{"plain secret", rfc6238SecretSha1, 59, "287082"},
+		{"plain secret with spaces and lower case", "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", 59, "287082"},
+		{"otpauth defaults", "otpauth://totp/test?secret=" + rfc6238SecretSha1, 1111111109, "081804"},
+		{"otpauth period", "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&digits=8&period=60", 119, "94287082"},
+		{"otpauth algorithm in lower case", "otpauth://totp/test?secret=" + rfc6238SecretSha256 + "&digits=8&algorithm=sha256", 59, "46119246"},
+		{"steam", "steam://" + rfc6238SecretSha1, 59, "PV9M4"},
+		{"steam later", "steam://" + rfc6238SecretSha1, 1234567890, "VHHQY"},
+		{"otpauth steam encoder", "otpauth://totp/Steam:test?secret=" + rfc6238SecretSha1 + "&encoder=steam", 59, "PV9M4"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			totp, err := parseTotpKey(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code := totp.code(time.Unix(test.time, 0)); code != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, code)
+			}
+		})
+	}
+}
+
+func TestParseTotpKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		digits    int
+		period    int
+		steam     bool
+		expectErr bool
+	}{
+		{name: "plain secret", key: rfc6238SecretSha1, digits: 6, period: 30},
+		{name: "otpauth", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&digits=8&period=60&algorithm=SHA512", digits: 8, period: 60},
+		{name: "otpauth upper case scheme", key: "OTPAUTH://totp/test?secret=" + rfc6238SecretSha1, digits: 6, period: 30},
+		{name: "steam", key: "steam://" + rfc6238SecretSha1, digits: 5, period: 30, steam: true},
+		{name: "otpauth steam encoder", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&encoder=steam&digits=8", digits: 5, period: 30, steam: true},
+		{name: "invalid base32 secret", key: "not base32!", expectErr: true},
+		{name: "base32 secret with invalid letters", key: "GEZDGNBV18", expectErr: true},
+		{name: "empty secret", key: "", expectErr: true},
+		{name: "otpauth without secret", key: "otpauth://totp/test?digits=6", expectErr: true},
+		{name: "otpauth invalid secret", key: "otpauth://totp/test?secret=1234", expectErr: true},
+		{name: "otpauth invalid digits", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&digits=eight", expectErr: true},
+		{name: "otpauth too many digits", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&digits=11", expectErr: true},
+		{name: "otpauth invalid period", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&period=0", expectErr: true},
+		{name: "otpauth unsupported algorithm", key: "otpauth://totp/test?secret=" + rfc6238SecretSha1 + "&algorithm=MD5", expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			totp, err := parseTotpKey(test.key)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", totp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if totp.digits != test.digits || totp.period != test.period || totp.steam != test.steam {
+				t.Errorf("expected digits %d, period %d, steam %t; got %d, %d, %t", test.digits, test.period, test.steam, totp.digits, totp.period, totp.steam)
+			}
+		})
+	}
+}
+
+func TestTotpSecondsRemaining(t *testing.T) {
+	totp, err := parseTotpKey(rfc6238SecretSha1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for unix, expected := range map[int64]int{0: 30, 1: 29, 29: 1, 59: 1, 60: 30} {
+		if remaining := totp.secondsRemaining(time.Unix(unix, 0)); remaining != expected {
+			t.Errorf("at %d: expected %d, got %d", unix, expected, remaining)
+		}
+	}
+}
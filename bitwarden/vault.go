@@ -0,0 +1,36 @@
+package bitwarden
+
+const (
+	backendCli = "cli"
+	backendApi = "api"
+)
+
+// vault reads items and folders; through bw (cliVault) or the server's http api (apiVault).
+// NOTE: writes always go through bw; the results are shaped like bw's, with itemKeyConversion applied to items.
+type vault interface {
+	getItem(id string) (*map[string]interface{}, error)
+	listItems(filters ...string) (*[]interface{}, error)
+	getFolder(id string) (*map[string]interface{}, error)
+	listFolders(filters ...string) (*[]interface{}, error)
+}
+
+// cliVault reads the vault with bw.
+type cliVault struct {
+	c *Client
+}
+
+func (v *cliVault) getItem(id string) (*map[string]interface{}, error) {
+	return v.c.bwGetItem(id)
+}
+
+func (v *cliVault) listItems(filters ...string) (*[]interface{}, error) {
+	return v.c.bwListItems(filters...)
+}
+
+func (v *cliVault) getFolder(id string) (*map[string]interface{}, error) {
+	return v.c.bwGetFolder(id)
+}
+
+func (v *cliVault) listFolders(filters ...string) (*[]interface{}, error) {
+	return v.c.bwListFolders(filters...)
+}
@@ -18,8 +18,154 @@ provider "bitwarden" {
   client_secret = local.creds["client_secret"]
   user_id = local.creds["user_id"]
 #  two_step_method = local.creds["two_step_method"]
+#  two_step_totp_secret = local.creds["two_step_totp_secret"]
   server = local.creds["server"]
+#  server_urls {
+#    api      = local.creds["api_url"]
+#    identity = local.creds["identity_url"]
+#  }
+#  ca_cert_file = "../internal-ca.pem"
+#  cli_path = "/opt/bitwarden/bw"
+#  backend = "api"
+#  cli_download {
+#    mirror_url = "https://mirror.example.com/bitwarden-cli"
+#    sha256     = local.creds["bw_zip_sha256"]
+#  }
 }
 
 data "bitwarden_items" "test" {}
 
+data "bitwarden_item" "test" {
+  id = local.creds["item_id"]
+}
+
+data "bitwarden_item" "test_by_name" {
+  name = "prod-postgres-admin"
+}
+
+
+resource "bitwarden_folder" "test" {
+  name = "terraform-provider-bitwarden test folder"
+}
+
+data "bitwarden_folder" "test" {
+  name = bitwarden_folder.test.name
+}
+
+resource "bitwarden_generated_password" "test" {
+  length  = 32
+  special = true
+
+  keepers = {
+    service = "postgres"
+  }
+}
+
+resource "bitwarden_item_login" "test" {
+  name      = "terraform-provider-bitwarden test login"
+  folder_id = data.bitwarden_folder.test.id
+  username  = "service-account"
+  password  = bitwarden_generated_password.test.result
+  totp      = "otpauth://totp/example?secret=JBSWY3DPEHPK3PXP&period=30"
+
+  uris {
+    uri   = "https://example.com/login"
+    match = "host"
+  }
+
+  fields {
+    name  = "environment"
+    value = "test"
+  }
+}
+
+resource "bitwarden_item_secure_note" "test" {
+  name  = "terraform-provider-bitwarden test note"
+  notes = file("${path.module}/kubeconfig")
+
+  organization_id = bitwarden_org_collection.test.organization_id
+  collection_ids  = [bitwarden_org_collection.test.id]
+}
+
+resource "bitwarden_item_card" "test" {
+  name            = "terraform-provider-bitwarden test card"
+  cardholder_name = "Example Corp"
+  brand           = "Visa"
+  number          = "4111111111111111"
+  exp_month       = "12"
+  exp_year        = "2030"
+}
+
+resource "bitwarden_item_identity" "test" {
+  name       = "terraform-provider-bitwarden test identity"
+  company    = "Example Corp"
+  first_name = "Jane"
+  last_name  = "Doe"
+  email      = "jane.doe@example.com"
+}
+
+resource "bitwarden_org_collection" "test" {
+  organization_id = local.creds["organization_id"]
+  name            = "terraform-provider-bitwarden test collection"
+}
+
+data "bitwarden_org_collection" "test" {
+  organization_id = bitwarden_org_collection.test.organization_id
+  name            = bitwarden_org_collection.test.name
+}
+
+data "bitwarden_organizations" "test" {}
+
+data "bitwarden_org_members" "test" {
+  organization_id = local.creds["organization_id"]
+}
+
+resource "bitwarden_send" "test" {
+  name             = "terraform-provider-bitwarden test send"
+  text             = bitwarden_item_login.test.password
+  hidden           = true
+  max_access_count = 1
+}
+
+output "send_url" {
+  value = bitwarden_send.test.access_url
+}
+
+resource "bitwarden_item_attachment" "test" {
+  item_id = bitwarden_item_secure_note.test.id
+  file    = "${path.module}/service-key.json"
+}
+
+data "bitwarden_item_attachment" "test" {
+  item_id = bitwarden_item_attachment.test.item_id
+  id      = bitwarden_item_attachment.test.id
+}
+
+data "bitwarden_totp" "test" {
+  item_id = bitwarden_item_login.test.id
+}
+
+resource "bitwarden_item_login" "test_rotated" {
+  name          = "terraform-provider-bitwarden test rotated login"
+  username      = "service-account"
+  rotation_days = 90
+
+  password_generator {
+    length  = 32
+    special = true
+  }
+}
+
+output "rotated_password" {
+  value     = bitwarden_item_login.test_rotated.generated_password
+  sensitive = true
+}
+
+output "environment" {
+  value     = data.bitwarden_item.test.fields_map["environment"]
+  sensitive = true
+}
+
+data "bitwarden_login_items" "test" {
+  uri = "https://example.com/login"
+}
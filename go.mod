@@ -4,5 +4,8 @@ go 1.15
 
 require (
 	github.com/hashicorp-demoapp/hashicups-client-go v0.0.0-20200508203820-4c67e90efb8e // indirect
+	github.com/hashicorp/go-uuid v1.0.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.3
+	github.com/mitchellh/mapstructure v1.1.2
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
@@ -8,6 +8,7 @@ import (
 )
 
 func main() {
+	defer bitwarden.Cleanup()
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return bitwarden.Provider()